@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-swagno/swagno/components/fields"
+)
+
+// loadComments parses the Go source files of the package directories dirs, skipping
+// tests and files excluded by build constraints, and returns the doc comments of their
+// type declarations and struct fields. A field without a doc comment uses its trailing
+// line comment. Import paths are derived from the enclosing go.mod, and packages named
+// main are keyed as "main" like reflect does.
+func loadComments(dirs ...string) (fields.Comments, error) {
+	comments := fields.Comments{}
+	for _, dir := range dirs {
+		pkgPath, err := importPath(dir)
+		if err != nil {
+			return nil, err
+		}
+		pkg, err := build.ImportDir(dir, 0)
+		if err != nil {
+			return nil, err
+		}
+		if pkg.Name == "main" {
+			pkgPath = "main"
+		}
+		fset := token.NewFileSet()
+		for _, name := range append(pkg.GoFiles, pkg.CgoFiles...) {
+			file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+			if err != nil {
+				return nil, err
+			}
+			collectComments(comments, pkgPath, file)
+		}
+	}
+	return comments, nil
+}
+
+// collectComments adds the doc comments of the types declared in file, and of the
+// fields of its struct types, to comments.
+func collectComments(comments fields.Comments, prefix string, file *ast.File) {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			key := prefix + "." + typeSpec.Name.Name
+
+			doc := typeSpec.Doc
+			if doc == nil && len(genDecl.Specs) == 1 {
+				doc = genDecl.Doc
+			}
+			if text := commentText(doc); text != "" {
+				comments[key] = text
+			}
+
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			for _, field := range structType.Fields.List {
+				text := commentText(field.Doc)
+				if text == "" {
+					text = commentText(field.Comment)
+				}
+				if text == "" {
+					continue
+				}
+				for _, name := range field.Names {
+					comments[key+"."+name.Name] = text
+				}
+				if len(field.Names) == 0 {
+					comments[key+"."+embeddedName(field.Type)] = text
+				}
+			}
+		}
+	}
+}
+
+func commentText(group *ast.CommentGroup) string {
+	return strings.TrimSpace(group.Text())
+}
+
+// embeddedName returns the field name of an embedded field of type expr.
+func embeddedName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.IndexExpr:
+		return embeddedName(e.X)
+	case *ast.IndexListExpr:
+		return embeddedName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+// importPath returns the import path of the package in dir, derived from the module
+// path declared by the closest go.mod in dir or its parents.
+func importPath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for root := abs; ; root = filepath.Dir(root) {
+		module, err := modulePath(filepath.Join(root, "go.mod"))
+		if err == nil {
+			rel, err := filepath.Rel(root, abs)
+			if err != nil {
+				return "", err
+			}
+			return path.Join(module, filepath.ToSlash(rel)), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		if filepath.Dir(root) == root {
+			return "", fmt.Errorf("no go.mod found for %s", dir)
+		}
+	}
+}
+
+func modulePath(goMod string) (string, error) {
+	file, err := os.Open(goMod)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s declares no module path", goMod)
+}
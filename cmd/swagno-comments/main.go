@@ -0,0 +1,46 @@
+// Command swagno-comments writes the doc comments of the types and struct fields of
+// Go packages to a JSON comment index, which is embedded in a binary and passed to
+// Config.Comments so the comments describe the generated definitions and properties.
+//
+// Usage:
+//
+//	swagno-comments [-o file] [package directories...]
+//
+// It is typically run by go:generate next to the code generating the documentation:
+//
+//	//go:generate go run github.com/go-swagno/swagno/cmd/swagno-comments -o comments.json ./models
+//	//go:embed comments.json
+//	var comments []byte
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	out := flag.String("o", "swagno_comments.json", "file to write the comment index to")
+	flag.Parse()
+
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	if err := run(*out, dirs); err != nil {
+		fmt.Fprintln(os.Stderr, "swagno-comments:", err)
+		os.Exit(1)
+	}
+}
+
+func run(out string, dirs []string) error {
+	comments, err := loadComments(dirs...)
+	if err != nil {
+		return err
+	}
+	data, err := comments.JSON()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, append(data, '\n'), 0o644)
+}
@@ -7,11 +7,12 @@ import (
 )
 
 // NameCollisionError is returned by ToJson (and panicked by MustToJson) when the
-// HidePackageName option causes two or more distinct, package-qualified types to
-// map to the same stripped name. The generated document would otherwise silently
+// HidePackageName option, the NameStrategy or the naming of generic type
+// instantiations by Config.GenericName causes two or more distinct,
+// package-qualified types to map to the same name. The generated document would otherwise silently
 // drop one of the colliding schemas, so generation fails instead.
 type NameCollisionError struct {
-	// Collisions maps each colliding stripped name to the sorted list of full
+	// Collisions maps each colliding name to the sorted list of full
 	// (package-qualified) type names that produced it.
 	Collisions map[string][]string
 }
@@ -29,13 +30,13 @@ func (e *NameCollisionError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"swagno: HidePackageName name collision: %s; rename one of the types or set HidePackageName to false",
+		"swagno: name collision: %s; rename one of the types or use a NameStrategy that tells them apart",
 		strings.Join(parts, "; "),
 	)
 }
 
-// collisionError inspects the recorded stripped-name -> full-type-name sets and
-// returns a *NameCollisionError if any stripped name was produced by more than one
+// collisionError inspects the recorded name -> full-type-name sets and
+// returns a *NameCollisionError if any name was produced by more than one
 // distinct type. It returns nil when there are no collisions.
 func collisionError(definitionTypeNames map[string]map[string]struct{}) error {
 	var collisions map[string][]string
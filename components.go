@@ -0,0 +1,110 @@
+package swagno
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-swagno/swagno/components/endpoint"
+	"github.com/go-swagno/swagno/components/http/response"
+	"github.com/go-swagno/swagno/components/parameter"
+)
+
+// ComponentRefError is returned by ToJson (and panicked by MustToJson) when endpoints
+// reference parameters or responses by a name that was never registered, which would
+// leave a dangling '$ref' in the document.
+type ComponentRefError struct {
+	// Errors describes each unregistered reference, in the order the endpoints were added.
+	Errors []error
+}
+
+func (e *ComponentRefError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return "swagno: unregistered components: " + strings.Join(messages, "; ")
+}
+
+// RegisterParameter registers a reusable parameter under the top-level parameters, which
+// endpoints reference by name with endpoint.WithParamRefs, e.g.
+//
+//	sw.RegisterParameter("page", parameter.IntParam("page", parameter.Query))
+func (s *Swagger) RegisterParameter(name string, p *parameter.Parameter) {
+	if s.parameters == nil {
+		s.parameters = make(map[string]*parameter.Parameter)
+	}
+	s.parameters[name] = p
+}
+
+// RegisterResponse registers a reusable response under the top-level responses, which
+// endpoints reference by name with endpoint.WithResponseRefs. The response is documented
+// under its return code wherever it is referenced, e.g.
+//
+//	sw.RegisterResponse("Unauthorized", response.New(ErrorResponse{}, "401", "Unauthorized"))
+func (s *Swagger) RegisterResponse(name string, resp response.Response) {
+	if s.responses == nil {
+		s.responses = make(map[string]response.Response)
+	}
+	s.responses[name] = resp
+}
+
+// responseNames returns the names of the registered responses in order, so their
+// definitions are generated deterministically.
+func (s *Swagger) responseNames() []string {
+	names := make([]string, 0, len(s.responses))
+	for name := range s.responses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// generateComponents adds the registered parameters and responses to the top-level
+// parameters and responses of the document.
+func (s *Swagger) generateComponents(responseGenerator *response.ResponseGenerator) {
+	for name, param := range s.parameters {
+		if s.Parameters == nil {
+			s.Parameters = make(map[string]parameter.JsonParameter)
+		}
+		s.Parameters[name] = s.paramJson(param)
+	}
+	for _, name := range s.responseNames() {
+		if s.Responses == nil {
+			s.Responses = make(map[string]endpoint.JsonResponse)
+		}
+		resp := s.responses[name]
+		s.Responses[name] = appendResponses(map[string]endpoint.JsonResponse{}, []response.Response{resp}, responseGenerator)[resp.ReturnCode()]
+	}
+}
+
+// refParams returns the registered parameters e references, and an error for each
+// reference to an unregistered parameter.
+func (s *Swagger) refParams(e *endpoint.EndPoint, path string) ([]*parameter.Parameter, []error) {
+	var params []*parameter.Parameter
+	var errs []error
+	for _, name := range e.ParamRefs() {
+		param, ok := s.parameters[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s %s: parameter %q is not registered", e.Method(), path, name))
+			continue
+		}
+		params = append(params, param)
+	}
+	return params, errs
+}
+
+// appendResponseRefs documents the registered responses e references under their return
+// codes in responses, and returns an error for each reference to an unregistered response.
+func (s *Swagger) appendResponseRefs(responses map[string]endpoint.JsonResponse, e *endpoint.EndPoint, path string) []error {
+	var errs []error
+	for _, name := range e.ResponseRefs() {
+		resp, ok := s.responses[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s %s: response %q is not registered", e.Method(), path, name))
+			continue
+		}
+		responses[resp.ReturnCode()] = endpoint.JsonResponse{Ref: "#/responses/" + name}
+	}
+	return errs
+}
@@ -3,7 +3,7 @@ package definition
 import (
 	"fmt"
 	"reflect"
-	"strings"
+	"sort"
 
 	"github.com/go-swagno/swagno/components/fields"
 	"github.com/go-swagno/swagno/components/http/response"
@@ -12,20 +12,69 @@ import (
 // Definition represents a Swagger 2.0 schema definition for a type.
 // See: https://swagger.io/specification/v2/#definitionsObject
 type Definition struct {
-	Type       string                          `json:"type"`
-	Properties map[string]DefinitionProperties `json:"properties,omitempty"`
-	Required   []string                        `json:"required,omitempty"`
+	Type        string                     `json:"type"`
+	Format      string                     `json:"format,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Items       *DefinitionPropertiesItems `json:"items,omitempty"`
+	Enum        []interface{}              `json:"enum,omitempty"`
+	Example     interface{}                `json:"example,omitempty"`
+	Properties  Properties                 `json:"properties,omitempty"`
+	Required    []string                   `json:"required,omitempty"`
+
+	// XEnumVarNames names the Enum values after the Go constants declaring them.
+	XEnumVarNames []string `json:"x-enum-varnames,omitempty"`
+	// XDiscriminator tells the implementations of a polymorphic definition apart.
+	XDiscriminator *Discriminator `json:"x-discriminator,omitempty"`
+}
+
+// Discriminator names the property identifying the implementation of an interface
+// type and maps its values to their definitions. Swagger 2.0 only supports a plain
+// property name as discriminator, so it is emitted as the 'x-discriminator' extension.
+type Discriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
+}
+
+// SchemaProvider is implemented by types that describe their own schema, such as
+// custom marshalers for enums, money types or union wrappers. The returned
+// Definition is used as-is instead of the one reflection would produce.
+// Both value and pointer receivers are supported.
+type SchemaProvider interface {
+	SwaggerSchema() Definition
 }
 
 // DefinitionProperties defines the details of a property within a Definition,
 // which may include its type, format, reference to another definition, among others.
 // See: https://swagger.io/specification/v2/#schemaObject
 type DefinitionProperties struct {
-	Type    string                     `json:"type,omitempty"`
-	Format  string                     `json:"format,omitempty"`
-	Ref     string                     `json:"$ref,omitempty"`
-	Items   *DefinitionPropertiesItems `json:"items,omitempty"`
-	Example interface{}                `json:"example,omitempty"`
+	Type             string                     `json:"type,omitempty"`
+	Format           string                     `json:"format,omitempty"`
+	Ref              string                     `json:"$ref,omitempty"`
+	Items            *DefinitionPropertiesItems `json:"items,omitempty"`
+	Example          interface{}                `json:"example,omitempty"`
+	Description      string                     `json:"description,omitempty"`
+	Default          interface{}                `json:"default,omitempty"`
+	Enum             []interface{}              `json:"enum,omitempty"`
+	Minimum          *float64                   `json:"minimum,omitempty"`
+	Maximum          *float64                   `json:"maximum,omitempty"`
+	ExclusiveMinimum bool                       `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum bool                       `json:"exclusiveMaximum,omitempty"`
+	MinLength        *int64                     `json:"minLength,omitempty"`
+	MaxLength        *int64                     `json:"maxLength,omitempty"`
+	Pattern          string                     `json:"pattern,omitempty"`
+	MinItems         *int64                     `json:"minItems,omitempty"`
+	MaxItems         *int64                     `json:"maxItems,omitempty"`
+	UniqueItems      bool                       `json:"uniqueItems,omitempty"`
+	ReadOnly         bool                       `json:"readOnly,omitempty"`
+	Properties       Properties                 `json:"properties,omitempty"`
+	Required         []string                   `json:"required,omitempty"`
+
+	// Swagger 2.0 has no keywords for these, they are documented as vendor extensions
+	XNullable   bool `json:"x-nullable,omitempty"`
+	XWriteOnly  bool `json:"x-writeOnly,omitempty"`
+	XDeprecated bool `json:"x-deprecated,omitempty"`
+
+	AdditionalProperties *DefinitionProperties `json:"additionalProperties,omitempty"`
 
 	// keep this info to fill Required fields later
 	IsRequired bool `json:"-"`
@@ -34,8 +83,18 @@ type DefinitionProperties struct {
 // DefinitionPropertiesItems specifies the type or reference of array items when
 // the 'type' of DefinitionProperties is set to 'array'.
 type DefinitionPropertiesItems struct {
-	Type string `json:"type,omitempty"`
-	Ref  string `json:"$ref,omitempty"`
+	Type     string                     `json:"type,omitempty"`
+	Format   string                     `json:"format,omitempty"`
+	Ref      string                     `json:"$ref,omitempty"`
+	Items    *DefinitionPropertiesItems `json:"items,omitempty"`
+	Enum     []interface{}              `json:"enum,omitempty"`
+	Minimum  *float64                   `json:"minimum,omitempty"`
+	MinItems *int64                     `json:"minItems,omitempty"`
+	MaxItems *int64                     `json:"maxItems,omitempty"`
+
+	Properties           Properties            `json:"properties,omitempty"`
+	Required             []string              `json:"required,omitempty"`
+	AdditionalProperties *DefinitionProperties `json:"additionalProperties,omitempty"`
 }
 
 // DefinitionGenerator holds a map of Definition objects and is capable
@@ -45,11 +104,40 @@ type DefinitionGenerator struct {
 	// HidePackageName, when true, strips the leading package qualifier from
 	// definition names and $ref values (e.g. "models.MyStruct" -> "MyStruct").
 	HidePackageName bool
-	// DefinitionTypeNames records, per stripped definition name, the set of full
+	// DefinitionTypeNames records, per definition name, the set of full
 	// (package-qualified) type names that produced it. It is used to detect
-	// collisions when HidePackageName is enabled. The map is shared across
-	// generator instances so it accumulates across all definitions of a document.
+	// collisions caused by HidePackageName or the naming of generic types. The map
+	// is shared across generator instances so it accumulates across all definitions
+	// of a document.
 	DefinitionTypeNames map[string]map[string]struct{}
+	// Types holds the user registered type mappings. Registered types, as well as
+	// the built-in mappings for standard library types, are documented inline
+	// instead of being reflected into a definition.
+	Types fields.Types
+	// Enums holds the user registered enum values. Properties and array items of
+	// these types, or of types implementing fields.Enumer, carry an 'enum'.
+	Enums fields.Enums
+	// EnumSchemas, when true, documents enum types as reusable definitions that
+	// their properties and array items reference.
+	EnumSchemas bool
+	// Interfaces holds the registered implementations of interface types. Fields of
+	// these types reference a polymorphic definition, other interface fields are
+	// documented as unconstrained values.
+	Interfaces fields.Interfaces
+	// NameStrategy names the definitions and their references. When nil, HidePackageName
+	// selects between fields.QualifiedNames and fields.UnqualifiedNames.
+	NameStrategy fields.NameStrategy
+	// ExampleErrors records, by message, the errors converting 'example' tags to the
+	// type of their field. Like DefinitionTypeNames, the map is shared across generator
+	// instances so it accumulates across all definitions of a document.
+	ExampleErrors map[string]error
+	// Comments holds the doc comments of Go types and struct fields, which describe
+	// the definitions and properties without an explicit description.
+	Comments fields.Comments
+	// visited holds the struct types whose definitions are created, or being created,
+	// by the current CreateDefinition call, so recursive types are referenced
+	// instead of being reflected again.
+	visited map[reflect.Type]bool
 }
 
 // NewDefinitionGenerator is a constructor function that initializes
@@ -62,11 +150,29 @@ func NewDefinitionGenerator(definitionMap map[string]Definition, hidePackageName
 	}
 }
 
-// recordName tracks that the stripped definition name shortName was produced by
-// the full (package-qualified) type name fullName. It is a no-op unless
-// HidePackageName is enabled, since collisions can only arise from stripping.
+// name returns the name of the definition of t.
+func (g DefinitionGenerator) name(t reflect.Type) string {
+	return fields.DefinitionName(t, g.NameStrategy, g.HidePackageName)
+}
+
+// example returns the example of field converted to the field's type. Examples that
+// cannot be converted are recorded in ExampleErrors and documented as the raw string.
+func (g DefinitionGenerator) example(field reflect.StructField) interface{} {
+	value, err := fields.Example(field, g.Types)
+	if err != nil {
+		if g.ExampleErrors != nil {
+			g.ExampleErrors[err.Error()] = err
+		}
+		return field.Tag.Get("example")
+	}
+	return value
+}
+
+// recordName tracks that the definition name shortName was produced by the full
+// (package-qualified) type name fullName. Names are shortened by HidePackageName
+// and by the naming of generic types, so distinct types may share a name.
 func (g DefinitionGenerator) recordName(shortName, fullName string) {
-	if !g.HidePackageName || g.DefinitionTypeNames == nil {
+	if g.DefinitionTypeNames == nil {
 		return
 	}
 	set, ok := g.DefinitionTypeNames[shortName]
@@ -79,275 +185,580 @@ func (g DefinitionGenerator) recordName(shortName, fullName string) {
 
 // CreateDefinition analyzes the type of the provided value 't' and adds a corresponding Definition to the generator's Definitions map.
 func (g DefinitionGenerator) CreateDefinition(t interface{}) {
-	properties := make(map[string]DefinitionProperties)
+	properties := Properties{}
 	fullName := fmt.Sprintf("%T", t)
-	definitionName := fields.RefName(fullName, g.HidePackageName)
+	definitionName := g.name(reflect.TypeOf(t))
 
 	reflectReturn := reflect.TypeOf(t)
-	switch reflectReturn.Kind() {
-	case reflect.Slice:
-		reflectReturn = reflectReturn.Elem()
-		if reflectReturn.Kind() == reflect.Struct {
-			properties = g.createStructDefinitions(reflectReturn)
+	if reflectReturn == nil {
+		return
+	}
+	if _, ok := g.Types.Lookup(reflectReturn); ok {
+		return // registered types are documented inline
+	}
+	if provider, ok := schemaProvider(reflectReturn); ok {
+		g.recordName(definitionName, fullName)
+		g.Definitions[definitionName] = provider.SwaggerSchema()
+		return
+	}
+	if enum, ok := g.Enums.Lookup(reflectReturn); ok && g.EnumSchemas {
+		g.recordName(definitionName, fullName)
+		description, _ := g.Comments.Type(reflectReturn)
+		g.Definitions[definitionName] = Definition{
+			Type:          fields.Type(reflectReturn.Kind().String()),
+			Format:        fields.Format(reflectReturn.Kind()),
+			Description:   description,
+			Enum:          enum.Values,
+			XEnumVarNames: enum.VarNames,
 		}
-		definitionName, _ = strings.CutPrefix(definitionName, "[]")
-		fullName, _ = strings.CutPrefix(fullName, "[]")
+		return
+	}
+	switch reflectReturn.Kind() {
+	case reflect.Pointer:
+		g.CreateDefinition(reflect.New(reflectReturn.Elem()).Elem().Interface())
+		return
+	case reflect.Slice, reflect.Array, reflect.Map:
+		// collections are documented inline, only the definitions of their elements are needed
+		g.typeProperty(reflectReturn)
+		return
 	case reflect.Struct:
 		if reflectReturn == reflect.TypeOf(response.CustomResponse{}) {
 			// if CustomResponseType, use Model struct in it
 			g.CreateDefinition(t.(response.CustomResponse).Model)
 			return
 		}
-		properties = g.createStructDefinitions(reflectReturn)
+		if reflectReturn.Name() == "" {
+			// anonymous structs are documented inline
+			g.typeProperty(reflectReturn)
+			return
+		}
+		if g.visited == nil {
+			g.visited = map[reflect.Type]bool{}
+		}
+		if g.visited[reflectReturn] {
+			return // already created, or being created by a recursive reference
+		}
+		g.visited[reflectReturn] = true
+		properties = g.createStructDefinitions(fields.JSONFields(reflectReturn))
+	default:
+		return // primitives are documented inline
 	}
 
-	// merge embedded struct fields with other fields
-	g.mergeEmbeddedStructFields(properties)
-
-	// delete empty json tags
-	delete(properties, "")
-
 	g.recordName(definitionName, fullName)
+	description, _ := g.Comments.Type(reflectReturn)
 	g.Definitions[definitionName] = Definition{
-		Type:       "object",
-		Properties: properties,
-		Required:   g.findRequiredFields(properties),
+		Type:        "object",
+		Description: description,
+		Properties:  properties,
+		Required:    g.findRequiredFields(properties),
 	}
 }
 
-func (g DefinitionGenerator) mergeEmbeddedStructFields(properties map[string]DefinitionProperties) {
-	for k, v := range properties {
-		if k == "" && v.Ref != "" { // identify embedded structs
-			embeddedModelName, _ := strings.CutPrefix(v.Ref, "#/definitions/")
-			if def, ok := g.Definitions[embeddedModelName]; ok {
-				for propName, propValue := range def.Properties {
-					properties[propName] = propValue
-				}
-				delete(properties, "")
-			}
-		}
-	}
-}
-
-func (g DefinitionGenerator) findRequiredFields(properties map[string]DefinitionProperties) []string {
+func (g DefinitionGenerator) findRequiredFields(properties Properties) []string {
 	requiredFields := []string{}
-	for k, v := range properties {
-		if v.IsRequired {
-			requiredFields = append(requiredFields, k)
+	for _, property := range properties {
+		if property.Schema.IsRequired {
+			requiredFields = append(requiredFields, property.Name)
 		}
 	}
 	return requiredFields
 }
 
-func (g DefinitionGenerator) createStructDefinitions(structType reflect.Type) map[string]DefinitionProperties {
-	properties := make(map[string]DefinitionProperties)
-	for i := 0; i < structType.NumField(); i++ {
-		field := structType.Field(i)
+// createStructDefinitions returns the properties of the fields encoding/json serializes,
+// as returned by fields.JSONFields, in the same order.
+func (g DefinitionGenerator) createStructDefinitions(jsonFields []fields.Field) Properties {
+	properties := Properties{}
+	for _, jsonField := range jsonFields {
+		field := jsonField.StructField
 		fieldType := fields.Type(field.Type.Kind().String())
-		fieldJsonTag := fields.JsonTag(field)
+		fieldJsonTag := jsonField.Name
+
+		// skip for function and channel types, and pointers to them
+		if kind := fields.Deref(field.Type).Kind(); kind == reflect.Func || kind == reflect.Chan {
+			continue
+		}
 
-		// skip ignored tags
-		if fieldJsonTag == "-" {
+		// registered types are documented as-is instead of being reflected
+		if property, ok := g.registeredProperty(field); ok {
+			properties.Set(fieldJsonTag, applyTags(property, field))
 			continue
 		}
 
-		// skip for function and channel types
-		if fieldType == "func" || fieldType == "chan" {
+		// types describing their own schema are referenced by their definition
+		if property, ok := g.providedProperty(field); ok {
+			properties.Set(fieldJsonTag, property)
+			continue
+		}
+
+		// anonymous structs have no name to reference and are documented inline
+		if fields.AnonymousStruct(field.Type) {
+			property := g.typeProperty(field.Type)
+			property.Example = g.example(field)
+			property.IsRequired = g.isRequired(field)
+			if field.Type.Kind() == reflect.Pointer {
+				property.IsRequired = fields.IsRequired(field)
+			}
+			properties.Set(fieldJsonTag, applyTags(property, field))
+			continue
+		}
+
+		// named types with a fixed set of values carry their enum
+		if property, ok := g.enumProperty(field); ok {
+			properties.Set(fieldJsonTag, applyTags(property, field))
 			continue
 		}
 
 		// if item type is array, create Definition for array element type
 		switch fieldType {
 		case "array":
-			if field.Type.Elem().Kind() == reflect.Pointer { // []*type
-				if field.Type.Elem().Elem().Kind() == reflect.Struct { // []*struct
-					properties[fieldJsonTag] = DefinitionProperties{
-						Example: fields.ExampleTag(field),
-						Type:    fieldType,
-						Items: &DefinitionPropertiesItems{
-							Ref: fmt.Sprintf("#/definitions/%s", fields.RefName(field.Type.Elem().Elem().String(), g.HidePackageName)),
-						},
-						IsRequired: g.isRequired(field),
-					}
-					if structType == field.Type.Elem().Elem() {
-						continue // prevent recursion
-					}
-					g.CreateDefinition(reflect.New(field.Type.Elem().Elem()).Elem().Interface())
-				} else { // []*other
-					itemType := fields.Type(field.Type.Elem().Elem().Kind().String())
-					properties[fieldJsonTag] = DefinitionProperties{
-						Example: fields.ExampleTag(field),
-						Type:    fieldType,
-						Items: &DefinitionPropertiesItems{
-							Type: itemType,
-						},
-						IsRequired: g.isRequired(field),
-					}
-				}
-			} else if field.Type.Elem().Kind() == reflect.Struct { // []struct
-				properties[fieldJsonTag] = DefinitionProperties{
-					Example: fields.ExampleTag(field),
-					Type:    fieldType,
-					Items: &DefinitionPropertiesItems{
-						Ref: fmt.Sprintf("#/definitions/%s", fields.RefName(field.Type.Elem().String(), g.HidePackageName)),
-					},
-					IsRequired: g.isRequired(field),
-				}
-				if structType == field.Type.Elem() {
-					continue // prevent recursion
-				}
-				g.CreateDefinition(reflect.New(field.Type.Elem()).Elem().Interface())
-			} else { // []other
-				properties[fieldJsonTag] = DefinitionProperties{
-					Example: fields.ExampleTag(field),
-					Type:    fieldType,
-					Items: &DefinitionPropertiesItems{
-						Type: fields.Type(field.Type.Elem().Kind().String()),
-					},
-					IsRequired: g.isRequired(field),
-				}
-			}
+			property := g.typeProperty(field.Type)
+			property.Example = g.example(field)
+			property.IsRequired = g.isRequired(field)
+			properties.Set(fieldJsonTag, property)
 
 		case "struct":
-			isRequiredField := g.isRequired(field)
-			if field.Type.String() == "time.Time" {
-				properties[fieldJsonTag] = g.timeProperty(field, isRequiredField)
-			} else if field.Type.String() == "time.Duration" {
-				properties[fieldJsonTag] = g.durationProperty(field, isRequiredField)
-			} else {
-				properties[fieldJsonTag] = DefinitionProperties{
-					Example:    fields.ExampleTag(field),
-					Ref:        fmt.Sprintf("#/definitions/%s", fields.RefName(field.Type.String(), g.HidePackageName)),
-					IsRequired: isRequiredField,
-				}
-				g.CreateDefinition(reflect.New(field.Type).Elem().Interface())
-			}
+			properties.Set(fieldJsonTag, DefinitionProperties{
+				Example:    g.example(field),
+				Ref:        fmt.Sprintf("#/definitions/%s", g.name(field.Type)),
+				IsRequired: g.isRequired(field),
+			})
+			g.CreateDefinition(reflect.New(field.Type).Elem().Interface())
 
 		case "ptr":
-			if field.Type.Elem() == structType { // prevent recursion
-				properties[fieldJsonTag] = DefinitionProperties{
-					Example: fmt.Sprintf("Recursive Type: %s", field.Type.Elem().String()),
-				}
-				continue
-			}
 			if field.Type.Elem().Kind() == reflect.Struct {
-				if field.Type.Elem().String() == "time.Time" {
-					properties[fieldJsonTag] = g.timeProperty(field, false)
-				} else if field.Type.String() == "time.Duration" {
-					properties[fieldJsonTag] = g.durationProperty(field, false)
-				} else {
-					properties[fieldJsonTag] = g.refProperty(field, false)
-					g.CreateDefinition(reflect.New(field.Type.Elem()).Elem().Interface())
-				}
-			} else if field.Type.Elem().Kind() == reflect.Array || field.Type.Elem().Kind() == reflect.Slice {
-				if field.Type.Elem().Elem().Kind() == reflect.Struct {
-					properties[fieldJsonTag] = DefinitionProperties{
-						Example: fields.ExampleTag(field),
-						Type:    fields.Type(field.Type.Elem().Kind().String()),
-						Items: &DefinitionPropertiesItems{
-							Ref: fmt.Sprintf("#/definitions/%s", fields.RefName(field.Type.Elem().Elem().String(), g.HidePackageName)),
-						},
-					}
-					if structType == field.Type.Elem().Elem() {
-						continue // prevent recursion
-					}
-					g.CreateDefinition(reflect.New(field.Type.Elem().Elem()).Elem().Interface())
-				} else {
-					properties[fieldJsonTag] = DefinitionProperties{
-						Example: fields.ExampleTag(field),
-						Type:    fields.Type(field.Type.Elem().Kind().String()),
-						Items: &DefinitionPropertiesItems{
-							Type: fields.Type(field.Type.Elem().Elem().Kind().String()),
-						},
-					}
-				}
+				properties.Set(fieldJsonTag, g.refProperty(field, fields.IsRequired(field)))
+				g.CreateDefinition(reflect.New(field.Type.Elem()).Elem().Interface())
 			} else {
-				properties[fieldJsonTag] = DefinitionProperties{
-					Example: fields.ExampleTag(field),
-					Type:    fields.Type(field.Type.Elem().Kind().String()),
-				}
+				// other pointers are documented like their element, e.g. interfaces, enums or collections
+				property := g.typeProperty(field.Type.Elem())
+				property.Example = g.example(field)
+				property.IsRequired = fields.IsRequired(field)
+				properties.Set(fieldJsonTag, property)
 			}
 
 		case "map":
-			name := fmt.Sprintf("%s.%s", fields.RefName(structType.String(), g.HidePackageName), fieldJsonTag)
-			g.recordName(name, fmt.Sprintf("%s.%s", structType.String(), fieldJsonTag))
-			mapKeyType := field.Type.Key()
-			mapValueType := field.Type.Elem()
-			if mapValueType.Kind() == reflect.Ptr {
-				mapValueType = mapValueType.Elem()
-			}
-			properties[fieldJsonTag] = DefinitionProperties{
-				Ref: fmt.Sprintf("#/definitions/%s", name),
-			}
-			if mapValueType.Kind() == reflect.Struct {
-				g.Definitions[name] = Definition{
-					Type: "object",
-					Properties: map[string]DefinitionProperties{
-						fields.Type(mapKeyType.String()): {
-							Ref: fmt.Sprintf("#/definitions/%s", fields.RefName(mapValueType.String(), g.HidePackageName)),
-						},
-					},
-				}
-			} else {
-				g.Definitions[name] = Definition{
-					Type: "object",
-					Properties: map[string]DefinitionProperties{
-						fields.Type(mapKeyType.String()): {
-							Example: fields.ExampleTag(field),
-							Type:    fields.Type(mapValueType.String()),
-						},
-					},
-				}
-			}
+			property := g.typeProperty(field.Type)
+			property.Example = g.example(field)
+			property.IsRequired = g.isRequired(field)
+			properties.Set(fieldJsonTag, property)
 
 		case "interface":
-			// TODO: Find a way to get real model of interface{}
-			properties[fieldJsonTag] = DefinitionProperties{
-				Example:    fields.ExampleTag(field),
-				Type:       "Ambiguous Type: interface{}",
-				IsRequired: g.isRequired(field),
-			}
+			properties.Set(fieldJsonTag, g.interfaceProperty(field))
 
 		default:
-			properties[fieldJsonTag] = g.defaultProperty(field)
+			properties.Set(fieldJsonTag, g.defaultProperty(field))
+
+		}
+
+		if property, ok := properties.Get(fieldJsonTag); ok {
+			properties.Set(fieldJsonTag, applyTags(property, field))
+		}
 
+		// values encoded by the ',string' option are JSON strings
+		if property, ok := properties.Get(fieldJsonTag); ok && jsonField.Quoted {
+			properties.Set(fieldJsonTag, quotedProperty(property))
 		}
 	}
 
+	// 'desc' tags, then doc comments, describe the properties without a description
+	for _, jsonField := range jsonFields {
+		property, ok := properties.Get(jsonField.Name)
+		if !ok || property.Description != "" {
+			continue
+		}
+		property.Description = fields.DescriptionTag(jsonField.StructField)
+		if property.Description == "" {
+			property.Description, _ = g.Comments.Field(jsonField.Owner, jsonField.StructField)
+		}
+		properties.Set(jsonField.Name, property)
+	}
 	return properties
 }
 
-func (g DefinitionGenerator) timeProperty(field reflect.StructField, required bool) DefinitionProperties {
+// quotedProperty converts the property of a field with the ',string' option, whose
+// value encoding/json writes as a JSON string, into a string property.
+func quotedProperty(property DefinitionProperties) DefinitionProperties {
+	property.Type = "string"
+	property.Format = ""
+	property.Ref = ""
+	property.Minimum, property.Maximum = nil, nil
+	property.ExclusiveMinimum, property.ExclusiveMaximum = false, false
+	if property.Enum != nil {
+		enum := make([]interface{}, len(property.Enum))
+		for i, value := range property.Enum {
+			enum[i] = fmt.Sprint(value)
+		}
+		property.Enum = enum
+	}
+	if property.Default != nil {
+		property.Default = fmt.Sprint(property.Default)
+	}
+	if property.Example != nil {
+		property.Example = fmt.Sprint(property.Example)
+	}
+	return property
+}
+
+// applyTags applies the validation constraints and the documentation annotations of the
+// struct tags of field to the property.
+func applyTags(property DefinitionProperties, field reflect.StructField) DefinitionProperties {
+	return applyAnnotations(applyConstraints(property, fields.Validation(field)), fields.Documentation(field))
+}
+
+// applyAnnotations copies the annotations of the documentation tags onto the property.
+// Like constraints, they are not applied to references, whose siblings are ignored.
+func applyAnnotations(property DefinitionProperties, a fields.Annotations) DefinitionProperties {
+	if property.Ref != "" {
+		return property
+	}
+	if a.Default != nil {
+		property.Default = a.Default
+	}
+	if a.Format != "" {
+		property.Format = a.Format
+	}
+	if a.Pattern != "" {
+		property.Pattern = a.Pattern
+	}
+	if a.ReadOnly {
+		property.ReadOnly = true
+	}
+
+	// Swagger 2.0 has no keywords for these, so vendor extensions document them
+	if a.Nullable {
+		property.XNullable = true
+	}
+	if a.WriteOnly {
+		property.XWriteOnly = true
+	}
+	if a.Deprecated {
+		property.XDeprecated = true
+	}
+	return property
+}
+
+// applyConstraints copies the validator derived constraints onto the property.
+// References are left untouched since siblings of a $ref are ignored.
+func applyConstraints(property DefinitionProperties, c fields.Constraints) DefinitionProperties {
+	if property.Ref != "" {
+		return property
+	}
+	if c.Minimum != nil {
+		property.Minimum = c.Minimum
+		property.ExclusiveMinimum = c.ExclusiveMinimum
+	}
+	if c.Maximum != nil {
+		property.Maximum = c.Maximum
+		property.ExclusiveMaximum = c.ExclusiveMaximum
+	}
+	if c.MinLength != nil {
+		property.MinLength = c.MinLength
+	}
+	if c.MaxLength != nil {
+		property.MaxLength = c.MaxLength
+	}
+	if c.MinItems != nil {
+		property.MinItems = c.MinItems
+	}
+	if c.MaxItems != nil {
+		property.MaxItems = c.MaxItems
+	}
+	if c.UniqueItems {
+		property.UniqueItems = true
+	}
+	if c.Pattern != "" {
+		property.Pattern = c.Pattern
+	}
+	if c.Format != "" {
+		property.Format = c.Format
+	}
+	if c.Enum != nil {
+		property.Enum = c.Enum
+	}
+	return property
+}
+
+// registeredProperty returns the property for a field whose (possibly pointer)
+// type is found in the type registry.
+func (g DefinitionGenerator) registeredProperty(field reflect.StructField) (DefinitionProperties, bool) {
+	schema, ok := g.Types.Lookup(field.Type)
+	if !ok {
+		return DefinitionProperties{}, false
+	}
+	required := g.isRequired(field)
+	if field.Type.Kind() == reflect.Pointer {
+		required = fields.IsRequired(field)
+	}
 	return DefinitionProperties{
-		Example:    fields.ExampleTag(field),
-		Type:       "string",
-		Format:     "date-time",
+		Example:    g.example(field),
+		Type:       schema.Type,
+		Format:     schema.Format,
 		IsRequired: required,
+	}, true
+}
+
+// knownProperty returns the property for a (possibly pointer) type that is found in
+// the type registry, implements SchemaProvider, is an interface or a map, or has
+// enum values, creating the definitions it references.
+func (g DefinitionGenerator) knownProperty(t reflect.Type) (DefinitionProperties, bool) {
+	if schema, ok := g.Types.Lookup(t); ok {
+		return DefinitionProperties{
+			Type:   schema.Type,
+			Format: schema.Format,
+		}, true
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if _, ok := schemaProvider(t); ok {
+		g.CreateDefinition(reflect.New(t).Elem().Interface())
+		return DefinitionProperties{
+			Ref: fmt.Sprintf("#/definitions/%s", g.name(t)),
+		}, true
+	}
+	if t.Kind() == reflect.Interface {
+		if g.createInterfaceDefinition(t) {
+			return DefinitionProperties{
+				Ref: fmt.Sprintf("#/definitions/%s", g.name(t)),
+			}, true
+		}
+		return DefinitionProperties{}, true
+	}
+	if t.Kind() == reflect.Map {
+		value := g.typeProperty(t.Elem())
+		return DefinitionProperties{
+			Type:                 "object",
+			AdditionalProperties: &value,
+		}, true
+	}
+	if enum, ok := g.Enums.Lookup(t); ok {
+		if g.EnumSchemas {
+			g.CreateDefinition(reflect.New(t).Elem().Interface())
+			return DefinitionProperties{
+				Ref: fmt.Sprintf("#/definitions/%s", g.name(t)),
+			}, true
+		}
+		property := primitiveProperty(t)
+		property.Enum = enum.Values
+		return property, true
+	}
+	return DefinitionProperties{}, false
+}
+
+// typeProperty returns the property describing values of type t, e.g. the values of
+// a map, creating the definitions of the structs it references.
+func (g DefinitionGenerator) typeProperty(t reflect.Type) DefinitionProperties {
+	if property, ok := g.knownProperty(t); ok {
+		return property
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		property := DefinitionProperties{
+			Type:  "array",
+			Items: asItems(g.typeProperty(t.Elem())),
+		}
+		if t.Kind() == reflect.Array {
+			// fixed-size arrays always hold exactly their length of elements
+			length := int64(t.Len())
+			property.MinItems, property.MaxItems = &length, &length
+		}
+		return property
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.inlineProperty(t)
+		}
+		name := g.name(t)
+		g.CreateDefinition(reflect.New(t).Elem().Interface())
+		return DefinitionProperties{
+			Ref: fmt.Sprintf("#/definitions/%s", name),
+		}
+	}
+	return primitiveProperty(t)
+}
+
+// primitiveProperty returns the property describing values of the kind of t, with
+// the format and lower bound of the numeric kinds.
+func primitiveProperty(t reflect.Type) DefinitionProperties {
+	return DefinitionProperties{
+		Type:    fields.Type(t.Kind().String()),
+		Format:  fields.Format(t.Kind()),
+		Minimum: fields.Minimum(t.Kind()),
+	}
+}
+
+// InlineProperty returns the property documenting values of type t in place, like the
+// values of struct fields: anonymous structs with their properties, named structs by
+// reference. It is used for anonymous struct bodies and responses, which have no
+// definition to reference.
+func (g DefinitionGenerator) InlineProperty(t reflect.Type) DefinitionProperties {
+	return g.typeProperty(t)
+}
+
+// inlineProperty returns the object property of an anonymous struct type, documenting
+// its fields in place.
+func (g DefinitionGenerator) inlineProperty(t reflect.Type) DefinitionProperties {
+	properties := g.createStructDefinitions(fields.JSONFields(t))
+	return DefinitionProperties{
+		Type:       "object",
+		Properties: properties,
+		Required:   g.findRequiredFields(properties),
+	}
+}
+
+// asItems converts the property describing the elements of an array into its items.
+func asItems(property DefinitionProperties) *DefinitionPropertiesItems {
+	return &DefinitionPropertiesItems{
+		Type:                 property.Type,
+		Format:               property.Format,
+		Ref:                  property.Ref,
+		Enum:                 property.Enum,
+		Minimum:              property.Minimum,
+		Items:                property.Items,
+		MinItems:             property.MinItems,
+		MaxItems:             property.MaxItems,
+		Properties:           property.Properties,
+		Required:             property.Required,
+		AdditionalProperties: property.AdditionalProperties,
+	}
+}
+
+// interfaceProperty returns a reference to the polymorphic definition of a field
+// whose interface type has registered implementations. Any value may be stored in
+// other interface fields, so they are documented without a type.
+func (g DefinitionGenerator) interfaceProperty(field reflect.StructField) DefinitionProperties {
+	property := DefinitionProperties{
+		Example:    g.example(field),
+		IsRequired: g.isRequired(field),
+	}
+	if g.createInterfaceDefinition(field.Type) {
+		property.Ref = fmt.Sprintf("#/definitions/%s", g.name(field.Type))
+	}
+	return property
+}
+
+// createInterfaceDefinition adds the definition of an interface type with registered
+// implementations, along with the definitions of the implementations. The interface
+// definition holds the discriminating property and maps its values to the implementations.
+// It reports false when t has no registered implementations.
+func (g DefinitionGenerator) createInterfaceDefinition(t reflect.Type) bool {
+	implementations, ok := g.Interfaces.Lookup(t)
+	if !ok {
+		return false
+	}
+	definitionName := g.name(t)
+	if _, ok := g.Definitions[definitionName]; ok {
+		return true // already created, or being created by a recursive implementation
+	}
+
+	values := make([]string, 0, len(implementations.Mapping))
+	for value := range implementations.Mapping {
+		values = append(values, value)
 	}
+	sort.Strings(values)
+
+	enum := make([]interface{}, len(values))
+	for i, value := range values {
+		enum[i] = value
+	}
+	discriminator := &Discriminator{
+		PropertyName: implementations.PropertyName,
+		Mapping:      map[string]string{},
+	}
+	g.recordName(definitionName, t.String())
+	g.Definitions[definitionName] = Definition{
+		Type: "object",
+		Properties: Properties{
+			{Name: implementations.PropertyName, Schema: DefinitionProperties{Type: "string", Enum: enum}},
+		},
+		Required:       []string{implementations.PropertyName},
+		XDiscriminator: discriminator,
+	}
+
+	for _, value := range values {
+		implementation := implementations.Mapping[value]
+		g.CreateDefinition(implementation)
+		discriminator.Mapping[value] = fmt.Sprintf("#/definitions/%s", g.name(reflect.TypeOf(implementation)))
+	}
+	return true
 }
 
-func (g DefinitionGenerator) durationProperty(field reflect.StructField, required bool) DefinitionProperties {
+// enumProperty returns the property for a field whose (possibly pointer) type has
+// enum values. With EnumSchemas the property references the enum's definition.
+func (g DefinitionGenerator) enumProperty(field reflect.StructField) (DefinitionProperties, bool) {
+	t := field.Type
+	required := g.isRequired(field)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		required = fields.IsRequired(field)
+	}
+	enum, ok := g.Enums.Lookup(t)
+	if !ok {
+		return DefinitionProperties{}, false
+	}
+	if g.EnumSchemas {
+		g.CreateDefinition(reflect.New(t).Elem().Interface())
+		return DefinitionProperties{
+			Example:    g.example(field),
+			Ref:        fmt.Sprintf("#/definitions/%s", g.name(t)),
+			IsRequired: required,
+		}, true
+	}
+	property := primitiveProperty(t)
+	property.Example = g.example(field)
+	property.Enum = enum.Values
+	property.IsRequired = required
+	return property, true
+}
+
+// providedProperty returns a reference to the definition of a field whose
+// (possibly pointer) type implements SchemaProvider, creating the definition.
+func (g DefinitionGenerator) providedProperty(field reflect.StructField) (DefinitionProperties, bool) {
+	t := field.Type
+	required := g.isRequired(field)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		required = fields.IsRequired(field)
+	}
+	if _, ok := schemaProvider(t); !ok {
+		return DefinitionProperties{}, false
+	}
+	g.CreateDefinition(reflect.New(t).Elem().Interface())
 	return DefinitionProperties{
-		Example:    fields.ExampleTag(field),
-		Type:       "integer",
+		Example:    g.example(field),
+		Ref:        fmt.Sprintf("#/definitions/%s", g.name(t)),
 		IsRequired: required,
+	}, true
+}
+
+// schemaProvider returns the SchemaProvider implemented by t, or by a pointer to t.
+// Pointer types are resolved to their element type first.
+func schemaProvider(t reflect.Type) (SchemaProvider, bool) {
+	if t == nil {
+		return nil, false
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
 	}
+	provider, ok := reflect.New(t).Interface().(SchemaProvider)
+	return provider, ok
 }
 
 func (g DefinitionGenerator) refProperty(field reflect.StructField, required bool) DefinitionProperties {
 	return DefinitionProperties{
-		Example:    fields.ExampleTag(field),
-		Ref:        fmt.Sprintf("#/definitions/%s", fields.RefName(field.Type.Elem().String(), g.HidePackageName)),
+		Example:    g.example(field),
+		Ref:        fmt.Sprintf("#/definitions/%s", g.name(field.Type.Elem())),
 		IsRequired: required,
 	}
 }
 
 func (g DefinitionGenerator) defaultProperty(field reflect.StructField) DefinitionProperties {
-	return DefinitionProperties{
-		Example:    fields.ExampleTag(field),
-		Type:       fields.Type(field.Type.Kind().String()),
-		IsRequired: g.isRequired(field),
-	}
+	property := primitiveProperty(field.Type)
+	property.Example = g.example(field)
+	property.IsRequired = g.isRequired(field)
+	return property
 }
 
 func (g DefinitionGenerator) isRequired(field reflect.StructField) bool {
@@ -1,10 +1,8 @@
 package endpoint
 
 import (
-	"fmt"
-	"reflect"
+	"encoding/json"
 
-	"github.com/go-swagno/swagno/components/fields"
 	"github.com/go-swagno/swagno/components/http/response"
 	"github.com/go-swagno/swagno/components/mime"
 	"github.com/go-swagno/swagno/components/parameter"
@@ -41,10 +39,20 @@ type JsonEndPoint struct {
 // It encapsulates the description and schema of a response object.
 // See: https://swagger.io/specification/v2/#response-object
 type JsonResponse struct {
+	Ref         string                        `json:"$ref,omitempty"`
 	Description string                        `json:"description"`
 	Schema      *parameter.JsonResponseSchema `json:"schema,omitempty"`
 }
 
+// MarshalJSON documents responses with a Ref as a reference object, holding nothing but the '$ref'.
+func (r JsonResponse) MarshalJSON() ([]byte, error) {
+	if r.Ref != "" {
+		return json.Marshal(map[string]string{"$ref": r.Ref})
+	}
+	type alias JsonResponse
+	return json.Marshal(alias(r))
+}
+
 // EndPoint holds the details of an API endpoint, including HTTP method, path, parameters,
 // request body, responses, and metadata such as tags and security requirements.
 type EndPoint struct {
@@ -64,6 +72,8 @@ type EndPoint struct {
 	consume           []mime.MIME
 	produce           []mime.MIME
 	security          []map[string][]string
+	paramRefs         []string
+	responseRefs      []string
 }
 
 // AsJson converts an EndPoint into its JSON representation as JsonEndPoint.
@@ -125,22 +135,32 @@ func (e *EndPoint) Path() string {
 	return e.path
 }
 
+// ParamRefs returns the names of the registered parameters the EndPoint references.
+func (e *EndPoint) ParamRefs() []string {
+	return e.paramRefs
+}
+
+// ResponseRefs returns the names of the registered responses the EndPoint references.
+func (e *EndPoint) ResponseRefs() []string {
+	return e.responseRefs
+}
+
 // BodyJsonParameter makes the body definitions and parameter for body if present. Parameters for body are described via schema
 // definition so that's why it doesn't use the 'Parameter' object like the other ones.
+// The schema is generated by a ResponseGenerator without registered types, enums or
+// interfaces, see BodyJsonParameterWith.
 func (e *EndPoint) BodyJsonParameter(hidePackageName bool) *parameter.JsonParameter {
-	if e.Body.Content != nil {
-		bodyRef := fmt.Sprintf("#/definitions/%s", fields.RefName(fmt.Sprintf("%T", e.Body.Content), hidePackageName))
-		bodySchema := parameter.JsonResponseSchema{
-			Ref: bodyRef,
-		}
+	return e.BodyJsonParameterWith(response.NewResponseGenerator(hidePackageName))
+}
 
-		if reflect.TypeOf(e.Body.Content).Kind() == reflect.Slice {
-			bodySchema = parameter.JsonResponseSchema{
-				Type: "array",
-				Items: &parameter.JsonResponseSchemeItems{
-					Ref: fmt.Sprintf("#/definitions/%s", fields.RefName(fmt.Sprintf("%T", e.Body.Content), hidePackageName)),
-				},
-			}
+// BodyJsonParameterWith makes the parameter for body if present, like BodyJsonParameter.
+// The schema is generated by the given ResponseGenerator, so structs are referenced by their definition
+// while primitives and collections are documented inline.
+func (e *EndPoint) BodyJsonParameterWith(generator *response.ResponseGenerator) *parameter.JsonParameter {
+	if e.Body.Content != nil {
+		bodySchema := generator.Generate(e.Body.Content)
+		if bodySchema == nil {
+			bodySchema = &parameter.JsonResponseSchema{Type: "object"}
 		}
 
 		p := &parameter.JsonParameter{
@@ -148,7 +168,7 @@ func (e *EndPoint) BodyJsonParameter(hidePackageName bool) *parameter.JsonParame
 			In:          "body",
 			Description: "body",
 			Required:    true,
-			Schema:      &bodySchema,
+			Schema:      bodySchema,
 		}
 
 		if e.Body.description != "" {
@@ -194,6 +214,20 @@ func WithParams(params ...*parameter.Parameter) EndPointOption {
 	}
 }
 
+// WithParamsFrom adds a parameter for each bound field of the struct value, e.g.
+// WithParamsFrom(ListOrdersRequest{}), as described by parameter.ParamsFrom.
+func WithParamsFrom(value interface{}) EndPointOption {
+	return WithParams(parameter.ParamsFrom(value)...)
+}
+
+// WithParamRefs references parameters registered with Swagger.RegisterParameter by name, so the
+// EndPoint documents them as '$ref: #/parameters/{name}' instead of repeating them.
+func WithParamRefs(names ...string) EndPointOption {
+	return func(e *EndPoint) {
+		e.paramRefs = append(e.paramRefs, names...)
+	}
+}
+
 type bodyOptions struct {
 	description string
 	required    *bool
@@ -245,6 +279,15 @@ func WithErrors(err []response.Response) EndPointOption {
 	}
 }
 
+// WithResponseRefs references responses registered with Swagger.RegisterResponse by name. Each is
+// documented under the return code it was registered with as '$ref: #/responses/{name}',
+// replacing a successful return or error with the same code.
+func WithResponseRefs(names ...string) EndPointOption {
+	return func(e *EndPoint) {
+		e.responseRefs = append(e.responseRefs, names...)
+	}
+}
+
 // WithDescription sets a descriptive text for the EndPoint, providing context or information about its purpose.
 func WithDescription(des string) EndPointOption {
 	return func(e *EndPoint) {
@@ -0,0 +1,32 @@
+package endpoint
+
+import (
+	"regexp"
+	"strings"
+)
+
+// pathParamPattern matches the '{name}' segments of a path template.
+var pathParamPattern = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// PathTemplate converts the gin (httprouter) style ':name' and '*name' segments of
+// path to '{name}' segments, e.g. "/users/:id/*file" becomes "/users/{id}/{file}".
+// Paths that are already templates are returned unchanged.
+func PathTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if len(segment) > 1 && (segment[0] == ':' || segment[0] == '*') {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// PathParamNames returns the names of the '{name}' segments of the path template, in
+// order of appearance.
+func PathParamNames(template string) []string {
+	var names []string
+	for _, match := range pathParamPattern.FindAllStringSubmatch(template, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
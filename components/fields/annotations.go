@@ -0,0 +1,119 @@
+package fields
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// Annotations holds the schema annotations given by the documentation struct tags of
+// a field, which describe a property beyond its type:
+//
+//	default:"10"         the default value, converted to the field's type
+//	format:"uuid"        the format, overriding the one derived from the type
+//	pattern:"^[a-z]+$"   the regular expression values match
+//	readonly:"true"      the value is only sent in responses
+//	writeonly:"true"     the value is only sent in requests
+//	deprecated:"true"    the property should no longer be used
+//	nullable:"true"      the value may be null
+type Annotations struct {
+	Default    interface{}
+	Format     string
+	Pattern    string
+	ReadOnly   bool
+	WriteOnly  bool
+	Deprecated bool
+	Nullable   bool
+}
+
+// Documentation parses the documentation struct tags of a field and returns the
+// annotations they describe. Defaults that cannot be converted to the field's type
+// are kept as the raw tag value.
+func Documentation(field reflect.StructField) Annotations {
+	a := Annotations{
+		Format:     field.Tag.Get("format"),
+		Pattern:    field.Tag.Get("pattern"),
+		ReadOnly:   boolTag(field, "readonly"),
+		WriteOnly:  boolTag(field, "writeonly"),
+		Deprecated: boolTag(field, "deprecated"),
+		Nullable:   boolTag(field, "nullable"),
+	}
+	if tagValue, ok := field.Tag.Lookup("default"); ok {
+		value, err := convertValue(field.Type, tagValue, nil)
+		if err != nil {
+			value = tagValue
+		}
+		a.Default = value
+	}
+	return a
+}
+
+func boolTag(field reflect.StructField, key string) bool {
+	value, _ := strconv.ParseBool(field.Tag.Get(key))
+	return value
+}
+
+// convertValue converts the tag value s to a value of type t. Strings are used as-is,
+// booleans and numbers are parsed and other types, such as slices, maps and structs,
+// are decoded from JSON. Types with a registered or built-in mapping follow the type
+// of their schema instead.
+func convertValue(t reflect.Type, s string, types Types) (interface{}, error) {
+	if schema, ok := types.Lookup(t); ok {
+		return convertSchemaValue(schema.Type, s)
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return s, nil
+	case reflect.Bool:
+		value, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, err
+		}
+		return value, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return nil, err
+		}
+		return value, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		value, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return nil, err
+		}
+		return value, nil
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+	value := reflect.New(t)
+	if err := json.Unmarshal([]byte(s), value.Interface()); err != nil {
+		return nil, err
+	}
+	return value.Elem().Interface(), nil
+}
+
+// convertSchemaValue converts the tag value s to a value of the schema type schemaType.
+func convertSchemaValue(schemaType, s string) (interface{}, error) {
+	switch schemaType {
+	case "string":
+		return s, nil
+	case "boolean":
+		return convertValue(reflect.TypeOf(false), s, nil)
+	case "integer":
+		return convertValue(reflect.TypeOf(int64(0)), s, nil)
+	case "number":
+		return convertValue(reflect.TypeOf(float64(0)), s, nil)
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
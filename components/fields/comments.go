@@ -0,0 +1,69 @@
+package fields
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Comments maps Go types and struct fields to their doc comments, which document the
+// definitions and properties generated for them. Types are keyed by their import path
+// and name, e.g. "github.com/acme/api/models.User", and fields by the key of their
+// struct followed by the Go field name, e.g. "github.com/acme/api/models.User.Email".
+//
+// Comments are loaded from source by the swagno-comments command, usually in a
+// go:generate step whose output is embedded in the binary and decoded with
+// ParseComments, since the sources are not available at runtime.
+type Comments map[string]string
+
+// Type returns the doc comment of the named type t. Pointer types are resolved to
+// their element type and instantiations of generic types share the comment of the
+// generic type. It is safe to call on a nil Comments.
+func (comments Comments) Type(t reflect.Type) (string, bool) {
+	key, ok := commentKey(t)
+	if !ok {
+		return "", false
+	}
+	comment, ok := comments[key]
+	return comment, ok
+}
+
+// Field returns the doc comment of the struct field declared by the struct type owner.
+// It is safe to call on a nil Comments.
+func (comments Comments) Field(owner reflect.Type, field reflect.StructField) (string, bool) {
+	key, ok := commentKey(owner)
+	if !ok {
+		return "", false
+	}
+	comment, ok := comments[key+"."+field.Name]
+	return comment, ok
+}
+
+func commentKey(t reflect.Type) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Name() == "" || t.PkgPath() == "" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(t.Name(), "[")
+	return t.PkgPath() + "." + name, true
+}
+
+// ParseComments decodes a comment index written by Comments.JSON, e.g. one embedded
+// with go:embed.
+func ParseComments(data []byte) (Comments, error) {
+	comments := Comments{}
+	if err := json.Unmarshal(data, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// JSON encodes the comment index, with keys sorted so the output is stable.
+func (comments Comments) JSON() ([]byte, error) {
+	return json.MarshalIndent(comments, "", "  ")
+}
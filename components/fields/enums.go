@@ -0,0 +1,51 @@
+package fields
+
+import "reflect"
+
+// Enumer is implemented by named types with a fixed set of allowed values,
+// such as `type OrderStatus string` with a block of constants.
+// Both value and pointer receivers are supported.
+type Enumer interface {
+	Enum() []interface{}
+}
+
+// EnumVarNamer can be implemented next to Enumer to name the allowed values,
+// in the same order, for the 'x-enum-varnames' extension of enum schemas.
+type EnumVarNamer interface {
+	EnumVarNames() []string
+}
+
+// Enum holds the allowed values of a named type and, optionally, the names of
+// the Go constants declaring them.
+type Enum struct {
+	Values   []interface{}
+	VarNames []string
+}
+
+// Enums maps named types to their allowed values.
+type Enums map[reflect.Type]Enum
+
+// Lookup returns the enum registered for t, falling back to the Enumer interface
+// implemented by t. Pointer types are resolved to their element type.
+// It is safe to call on a nil Enums.
+func (enums Enums) Lookup(t reflect.Type) (Enum, bool) {
+	if t == nil {
+		return Enum{}, false
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if enum, ok := enums[t]; ok {
+		return enum, true
+	}
+	value := reflect.New(t).Interface()
+	enumer, ok := value.(Enumer)
+	if !ok {
+		return Enum{}, false
+	}
+	enum := Enum{Values: enumer.Enum()}
+	if namer, ok := value.(EnumVarNamer); ok {
+		enum.VarNames = namer.EnumVarNames()
+	}
+	return enum, true
+}
@@ -0,0 +1,115 @@
+package fields
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// GenericNameFunc returns the definition name of an instantiation of a generic type, given
+// the name of the generic type and the names of its type arguments, e.g. "models.Page"
+// and ["Product"] for models.Page[github.com/acme/api/models.Product]. Type arguments
+// are named without their package qualifier, pointers are dereferenced, slices and
+// arrays get a "List" suffix and maps a "Map" suffix, e.g. "ProductList" for
+// []*models.Product.
+//
+// Names should only use the characters A-Z a-z 0-9 . - _ which are valid in
+// references. Different types mapping to the same name are reported as a
+// *NameCollisionError by ToJson.
+type GenericNameFunc func(name string, typeArgs []string) string
+
+// joinTypeArgs is the default GenericNameFunc, which joins the name and the type
+// arguments with underscores, e.g. "models.Page_Product".
+func joinTypeArgs(name string, typeArgs []string) string {
+	return name + "_" + strings.Join(typeArgs, "_")
+}
+
+// GenericNames returns the NameStrategy names with the instantiations of generic types
+// named by generic instead of joinTypeArgs. It applies to the built-in strategies; other
+// strategies are given the instantiated type and name it themselves, so they are
+// returned unchanged.
+func GenericNames(names NameStrategy, generic GenericNameFunc) NameStrategy {
+	strategy, ok := names.(genericStrategy)
+	if !ok || generic == nil {
+		return names
+	}
+	return NameFunc(func(t reflect.Type) string {
+		return strategy(t, generic)
+	})
+}
+
+// packageQualifier matches the import path qualifier of type names in the type
+// arguments of generic types, e.g. "github.com/acme/api/models.".
+var packageQualifier = regexp.MustCompile(`(?:[\w.\-]+/)*[\w\-]+\.`)
+
+// invalidNameChars matches runs of characters which are not valid in references.
+var invalidNameChars = regexp.MustCompile(`[^\w.\-]+`)
+
+// genericName returns the name of a generic type instantiation, as reported by
+// reflect, normalized by generic. Other names are returned unchanged.
+func genericName(name string, generic GenericNameFunc) string {
+	open := strings.Index(name, "[")
+	if open <= 0 || !strings.HasSuffix(name, "]") || strings.HasPrefix(name, "map[") {
+		return name
+	}
+	typeArgs := splitTypeArgs(name[open+1 : len(name)-1])
+	for i, typeArg := range typeArgs {
+		typeArgs[i] = typeArgName(packageQualifier.ReplaceAllString(typeArg, ""), generic)
+	}
+	return generic(name[:open], typeArgs)
+}
+
+// typeArgName returns the name of a type argument whose package qualifiers are removed.
+func typeArgName(typeArg string, generic GenericNameFunc) string {
+	switch {
+	case strings.HasPrefix(typeArg, "*"):
+		return typeArgName(typeArg[1:], generic)
+	case strings.HasPrefix(typeArg, "["):
+		return typeArgName(typeArg[closingBracket(typeArg, 0)+1:], generic) + "List"
+	case strings.HasPrefix(typeArg, "map["):
+		return typeArgName(typeArg[closingBracket(typeArg, 3)+1:], generic) + "Map"
+	case typeArg == "interface {}":
+		return "any"
+	case strings.Contains(typeArg, "["):
+		return genericName(typeArg, generic)
+	}
+	return strings.Trim(invalidNameChars.ReplaceAllString(typeArg, "_"), "_")
+}
+
+// splitTypeArgs splits a list of type arguments on the commas which are not nested
+// in the brackets of another type.
+func splitTypeArgs(list string) []string {
+	typeArgs := []string{}
+	depth, start := 0, 0
+	for i, c := range list {
+		switch c {
+		case '[', '{', '(':
+			depth++
+		case ']', '}', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				typeArgs = append(typeArgs, strings.TrimSpace(list[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(typeArgs, strings.TrimSpace(list[start:]))
+}
+
+// closingBracket returns the index of the bracket closing the one at index open.
+func closingBracket(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(s) - 1
+}
@@ -0,0 +1,243 @@
+package fields
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Constraints holds the schema constraints derived from the go-playground/validator
+// rules of a struct field. The `validate` tag is read first, followed by the
+// `binding` tag used by gin. Bounds are already resolved against the field's
+// kind, so e.g. `min=1` on a string becomes MinLength and on a slice MinItems.
+type Constraints struct {
+	Required         bool
+	Minimum          *float64
+	Maximum          *float64
+	ExclusiveMinimum bool
+	ExclusiveMaximum bool
+	MinLength        *int64
+	MaxLength        *int64
+	MinItems         *int64
+	MaxItems         *int64
+	UniqueItems      bool
+	Pattern          string
+	Format           string
+	Enum             []interface{}
+}
+
+// validatorFormats maps validator rules to the equivalent schema 'format'.
+var validatorFormats = map[string]string{
+	"email":    "email",
+	"url":      "uri",
+	"uri":      "uri",
+	"http_url": "uri",
+	"uuid":     "uuid",
+	"uuid3":    "uuid",
+	"uuid4":    "uuid",
+	"uuid5":    "uuid",
+	"ip":       "ip",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"hostname": "hostname",
+	"base64":   "byte",
+}
+
+// validatorPatterns maps validator rules to an equivalent schema 'pattern'.
+var validatorPatterns = map[string]string{
+	"alpha":       `^[a-zA-Z]+$`,
+	"alphanum":    `^[a-zA-Z0-9]+$`,
+	"numeric":     `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":      `^[0-9]+$`,
+	"hexadecimal": `^(0[xX])?[0-9a-fA-F]+$`,
+	"e164":        `^\+[1-9]?[0-9]{7,14}$`,
+}
+
+// Validation parses the 'validate' and 'binding' struct tags of a field and returns
+// the schema constraints they describe. Rules that have no schema equivalent are
+// ignored, as is everything after a 'dive' since those rules apply to elements.
+func Validation(field reflect.StructField) Constraints {
+	c := Constraints{}
+	kind := field.Type.Kind()
+	if kind == reflect.Pointer {
+		kind = field.Type.Elem().Kind()
+	}
+
+	for _, rule := range validationRules(field) {
+		name, arg, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			c.Required = true
+		case "min", "gte":
+			c.setLowerBound(kind, arg, false)
+		case "max", "lte":
+			c.setUpperBound(kind, arg, false)
+		case "gt":
+			c.setLowerBound(kind, arg, true)
+		case "lt":
+			c.setUpperBound(kind, arg, true)
+		case "len", "eq":
+			if name == "eq" && !isLengthKind(kind) {
+				c.setEnum(kind, arg)
+				continue
+			}
+			c.setLowerBound(kind, arg, false)
+			c.setUpperBound(kind, arg, false)
+		case "oneof":
+			c.setEnum(kind, arg)
+		case "unique":
+			c.UniqueItems = kind == reflect.Slice || kind == reflect.Array
+		case "datetime":
+			if arg == "2006-01-02" {
+				c.Format = "date"
+			} else {
+				c.Format = "date-time"
+			}
+		case "startswith":
+			c.Pattern = "^" + regexp.QuoteMeta(arg)
+		case "endswith":
+			c.Pattern = regexp.QuoteMeta(arg) + "$"
+		default:
+			if format, ok := validatorFormats[name]; ok {
+				c.Format = format
+			} else if pattern, ok := validatorPatterns[name]; ok {
+				c.Pattern = pattern
+			}
+		}
+	}
+
+	return c
+}
+
+// validationRules returns the rules of the 'validate' and 'binding' tags up to the
+// first 'dive'. Alternatives joined with '|' are skipped since a schema cannot express them.
+func validationRules(field reflect.StructField) []string {
+	rules := []string{}
+	for _, tag := range []string{"validate", "binding"} {
+		for _, rule := range strings.Split(field.Tag.Get(tag), ",") {
+			rule = strings.TrimSpace(rule)
+			if rule == "dive" {
+				break
+			}
+			if rule == "" || strings.Contains(rule, "|") {
+				continue
+			}
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+func isLengthKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// isNumberKind reports whether kind is documented as an integer or number, the only
+// kinds besides lengths that bounds apply to. Struct kinds such as time.Time are not.
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func (c *Constraints) setLowerBound(kind reflect.Kind, arg string, exclusive bool) {
+	switch kind {
+	case reflect.String:
+		if n, err := strconv.ParseInt(arg, 10, 64); err == nil {
+			if exclusive {
+				n++
+			}
+			c.MinLength = &n
+		}
+	case reflect.Slice, reflect.Array:
+		if n, err := strconv.ParseInt(arg, 10, 64); err == nil {
+			if exclusive {
+				n++
+			}
+			c.MinItems = &n
+		}
+	default:
+		if !isNumberKind(kind) {
+			return
+		}
+		if f, err := strconv.ParseFloat(arg, 64); err == nil {
+			c.Minimum = &f
+			c.ExclusiveMinimum = exclusive
+		}
+	}
+}
+
+func (c *Constraints) setUpperBound(kind reflect.Kind, arg string, exclusive bool) {
+	switch kind {
+	case reflect.String:
+		if n, err := strconv.ParseInt(arg, 10, 64); err == nil {
+			if exclusive {
+				n--
+			}
+			c.MaxLength = &n
+		}
+	case reflect.Slice, reflect.Array:
+		if n, err := strconv.ParseInt(arg, 10, 64); err == nil {
+			if exclusive {
+				n--
+			}
+			c.MaxItems = &n
+		}
+	default:
+		if !isNumberKind(kind) {
+			return
+		}
+		if f, err := strconv.ParseFloat(arg, 64); err == nil {
+			c.Maximum = &f
+			c.ExclusiveMaximum = exclusive
+		}
+	}
+}
+
+// setEnum converts the space separated 'oneof' values to the field's kind, so integer,
+// number and boolean fields get values of their schema type.
+// Values may be wrapped in single quotes to include spaces, as in the validator.
+func (c *Constraints) setEnum(kind reflect.Kind, arg string) {
+	c.Enum = []interface{}{}
+	for _, value := range splitOneOf(arg) {
+		switch kind {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				c.Enum = append(c.Enum, n)
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if n, err := strconv.ParseUint(value, 10, 64); err == nil {
+				c.Enum = append(c.Enum, n)
+			}
+		case reflect.Float32, reflect.Float64:
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				c.Enum = append(c.Enum, f)
+			}
+		case reflect.Bool:
+			if b, err := strconv.ParseBool(value); err == nil {
+				c.Enum = append(c.Enum, b)
+			}
+		default:
+			c.Enum = append(c.Enum, value)
+		}
+	}
+}
+
+var oneOfValues = regexp.MustCompile(`'[^']*'|\S+`)
+
+func splitOneOf(arg string) []string {
+	values := oneOfValues.FindAllString(arg, -1)
+	for i, v := range values {
+		values[i] = strings.Trim(v, "'")
+	}
+	return values
+}
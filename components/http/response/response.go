@@ -3,7 +3,6 @@ package response
 import (
 	"fmt"
 	"reflect"
-	"strings"
 
 	"github.com/go-swagno/swagno/components/fields"
 	"github.com/go-swagno/swagno/components/parameter"
@@ -27,6 +26,25 @@ type ResponseGenerator struct {
 	// HidePackageName, when true, strips the leading package qualifier from $ref
 	// values (e.g. "models.MyStruct" -> "MyStruct").
 	HidePackageName bool
+	// Types holds the user registered type mappings, which are used instead of
+	// reflection for registered types and the built-in standard library mappings.
+	Types fields.Types
+	// NameStrategy names the referenced definitions. When nil, HidePackageName selects
+	// between fields.QualifiedNames and fields.UnqualifiedNames.
+	NameStrategy fields.NameStrategy
+	// Enums holds the user registered enum values, which are documented on values of
+	// these types.
+	Enums fields.Enums
+	// EnumSchemas, when true, references the definitions of enum types instead of
+	// documenting their values inline.
+	EnumSchemas bool
+	// Interfaces holds the registered implementations of interface types, whose values
+	// reference the polymorphic definition of the interface.
+	Interfaces fields.Interfaces
+	// InlineSchema documents anonymous structs, and collections of them, which have no
+	// schema to reference, like the values of struct fields are documented. When nil,
+	// anonymous structs are documented as objects.
+	InlineSchema func(t reflect.Type) *parameter.JsonResponseSchema
 }
 
 // New creates a new instance of Response with the provided model return code, and description.
@@ -45,56 +63,119 @@ func NewResponseGenerator(hidePackageName bool) *ResponseGenerator {
 	}
 }
 
+// name returns the name of the definition of t.
+func (g ResponseGenerator) name(t reflect.Type) string {
+	return fields.DefinitionName(t, g.NameStrategy, g.HidePackageName)
+}
+
 // Generate generates a JSON response schema based on the provided model.
 // It uses reflection to determine the type of the model and constructs the appropriate JSON schema.
-// This function handles different types such as slices, maps, and structures to create a detailed and accurate schema.
+// Named structs are referenced by their definition while anonymous structs, primitives, pointers
+// to them and collections such as slices and maps are documented inline. It returns nil for
+// nil models and empty structs, which have no schema.
 func (g ResponseGenerator) Generate(model any) *parameter.JsonResponseSchema {
-	switch reflect.TypeOf(model).Kind() {
-	case reflect.Slice:
-		sliceElementKind := reflect.TypeOf(model).Elem().Kind()
-		if sliceElementKind == reflect.Struct {
-			return &parameter.JsonResponseSchema{
-				Type: "array",
-				Items: &parameter.JsonResponseSchemeItems{
-					Ref: fmt.Sprintf("#/definitions/%s", fields.RefName(strings.ReplaceAll(reflect.TypeOf(model).Elem().String(), "[]", ""), g.HidePackageName)),
-				},
-			}
-		} else {
+	t := reflect.TypeOf(model)
+	if t == nil {
+		return nil
+	}
+	if _, ok := g.Types.Lookup(t); !ok && !fields.ProvidesSchema(t) && isEmptyStruct(t) {
+		return nil
+	}
+	return g.valueSchema(t)
+}
+
+// valueSchema returns the schema of values of type t as found in slices and maps.
+// Maps are documented as objects with additionalProperties, named structs by reference
+// and anonymous structs inline.
+func (g ResponseGenerator) valueSchema(t reflect.Type) *parameter.JsonResponseSchema {
+	if schema, ok := g.Types.Lookup(t); ok {
+		return &parameter.JsonResponseSchema{
+			Type:   schema.Type,
+			Format: schema.Format,
+		}
+	}
+	if g.InlineSchema != nil && fields.AnonymousStruct(t) {
+		return g.InlineSchema(t)
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct && t.Name() == "" {
+		return &parameter.JsonResponseSchema{Type: "object"}
+	}
+	if t.Kind() == reflect.Struct || fields.ProvidesSchema(t) {
+		return &parameter.JsonResponseSchema{
+			Ref: fmt.Sprintf("#/definitions/%s", g.name(t)),
+		}
+	}
+
+	if enum, ok := g.Enums.Lookup(t); ok {
+		if g.EnumSchemas {
 			return &parameter.JsonResponseSchema{
-				Type: "array",
-				Items: &parameter.JsonResponseSchemeItems{
-					Type: fields.Type(sliceElementKind.String()),
-				},
+				Ref: fmt.Sprintf("#/definitions/%s", g.name(t)),
 			}
 		}
+		return &parameter.JsonResponseSchema{
+			Type:   fields.Type(t.Kind().String()),
+			Format: fields.Format(t.Kind()),
+			Min:    fields.Minimum(t.Kind()),
+			Enum:   enum.Values,
+		}
+	}
 
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		schema := &parameter.JsonResponseSchema{
+			Type:  "array",
+			Items: asItems(g.valueSchema(t.Elem())),
+		}
+		if t.Kind() == reflect.Array {
+			// fixed-size arrays always hold exactly their length of elements
+			length := int64(t.Len())
+			schema.MinItems, schema.MaxItems = &length, &length
+		}
+		return schema
 	case reflect.Map:
-		ref := fmt.Sprintf("#/definitions/%s", fields.RefName(strings.ReplaceAll(fmt.Sprintf("%T", model), "[]", ""), g.HidePackageName))
 		return &parameter.JsonResponseSchema{
-			Ref: ref,
+			Type:                 "object",
+			AdditionalProperties: g.valueSchema(t.Elem()),
 		}
-
-	default:
-		if hasStructFields(model) {
+	case reflect.Interface:
+		if _, ok := g.Interfaces.Lookup(t); ok {
 			return &parameter.JsonResponseSchema{
-				Ref: fmt.Sprintf("#/definitions/%s", fields.RefName(strings.ReplaceAll(fmt.Sprintf("%T", model), "[]", ""), g.HidePackageName)),
+				Ref: fmt.Sprintf("#/definitions/%s", g.name(t)),
 			}
 		}
+		return &parameter.JsonResponseSchema{}
+	}
+	return &parameter.JsonResponseSchema{
+		Type:   fields.Type(t.Kind().String()),
+		Format: fields.Format(t.Kind()),
+		Min:    fields.Minimum(t.Kind()),
 	}
-
-	return nil
 }
 
-// hasStructFields checks if the given interface has fields in case it's a struct.
-func hasStructFields(s interface{}) bool {
-	rv := reflect.ValueOf(s)
-
-	if rv.Kind() != reflect.Struct {
-		return false
+// asItems converts the schema of the elements of an array into its items.
+func asItems(schema *parameter.JsonResponseSchema) *parameter.JsonResponseSchemeItems {
+	return &parameter.JsonResponseSchemeItems{
+		Type:                 schema.Type,
+		Format:               schema.Format,
+		Ref:                  schema.Ref,
+		Items:                schema.Items,
+		Enum:                 schema.Enum,
+		Min:                  schema.Min,
+		MinItems:             schema.MinItems,
+		MaxItems:             schema.MaxItems,
+		AdditionalProperties: schema.AdditionalProperties,
 	}
+}
 
-	numFields := rv.NumField()
-	return numFields > 0
+// isEmptyStruct checks if t, or the type t points to, is a struct without fields.
+func isEmptyStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t.NumField() == 0
 }
 
 func (c CustomResponse) Description() string {
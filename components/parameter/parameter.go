@@ -1,8 +1,12 @@
 package parameter
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
+
+	"github.com/go-swagno/swagno/components/fields"
 )
 
 // CollectionFormat defines the format for serializing array parameters in the URL query string.
@@ -56,42 +60,83 @@ const (
 // JsonParameter is the JSON model version of Parameter object used for API purposes
 // https://swagger.io/specification/v2/#parameterObject
 type JsonParameter struct {
-	Type              string              `json:"type,omitempty"`
-	Description       string              `json:"description"`
-	Name              string              `json:"name"`
-	In                string              `json:"in,omitempty"`
-	Required          bool                `json:"required"`
-	Schema            *JsonResponseSchema `json:"schema,omitempty"`
-	Format            string              `json:"format,omitempty"`
-	Enum              []interface{}       `json:"enum,omitempty"`
-	Default           interface{}         `json:"default,omitempty"`
-	Min               int64               `json:"minimum,omitempty"`
-	Max               int64               `json:"maximum,omitempty"`
-	MinLen            int64               `json:"minLength,omitempty"`
-	MaxLen            int64               `json:"maxLength,omitempty"`
-	Pattern           string              `json:"pattern,omitempty"`
-	MaxItems          int64               `json:"maxItems,omitempty"`
-	MinItems          int64               `json:"minItems,omitempty"`
-	UniqueItems       bool                `json:"uniqueItems,omitempty"`
-	MultipleOf        int64               `json:"multipleOf,omitempty"`
-	CollenctionFormat string              `json:"collectionFormat,omitempty"`
+	Ref               string                   `json:"$ref,omitempty"`
+	Type              string                   `json:"type,omitempty"`
+	Description       string                   `json:"description"`
+	Name              string                   `json:"name"`
+	In                string                   `json:"in,omitempty"`
+	Required          bool                     `json:"required"`
+	Schema            *JsonResponseSchema      `json:"schema,omitempty"`
+	Format            string                   `json:"format,omitempty"`
+	Items             *JsonResponseSchemeItems `json:"items,omitempty"`
+	Enum              []interface{}            `json:"enum,omitempty"`
+	Default           interface{}              `json:"default,omitempty"`
+	Min               *float64                 `json:"minimum,omitempty"`
+	Max               *float64                 `json:"maximum,omitempty"`
+	ExclusiveMin      bool                     `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMax      bool                     `json:"exclusiveMaximum,omitempty"`
+	MinLen            int64                    `json:"minLength,omitempty"`
+	MaxLen            int64                    `json:"maxLength,omitempty"`
+	Pattern           string                   `json:"pattern,omitempty"`
+	MaxItems          int64                    `json:"maxItems,omitempty"`
+	MinItems          int64                    `json:"minItems,omitempty"`
+	UniqueItems       bool                     `json:"uniqueItems,omitempty"`
+	MultipleOf        *float64                 `json:"multipleOf,omitempty"`
+	CollenctionFormat string                   `json:"collectionFormat,omitempty"`
+	XDeprecated       bool                     `json:"x-deprecated,omitempty"`
+	XExample          interface{}              `json:"x-example,omitempty"`
+}
+
+// MarshalJSON documents parameters with a Ref as a reference object, holding nothing but the '$ref'.
+func (p JsonParameter) MarshalJSON() ([]byte, error) {
+	if p.Ref != "" {
+		return json.Marshal(map[string]string{"$ref": p.Ref})
+	}
+	type alias JsonParameter
+	return json.Marshal(alias(p))
 }
 
 // JsonResponseSchema defines the schema for a JSON response as per the Swagger 2.0 specification.
 // It is used to describe the structure and type of a response returned by an API endpoint.
 // https://swagger.io/specification/v2/#schema-object
 type JsonResponseSchema struct {
-	Ref   string                   `json:"$ref,omitempty"`
-	Type  string                   `json:"type,omitempty"`
-	Items *JsonResponseSchemeItems `json:"items,omitempty"`
+	Ref                  string                   `json:"$ref,omitempty"`
+	Type                 string                   `json:"type,omitempty"`
+	Format               string                   `json:"format,omitempty"`
+	Items                *JsonResponseSchemeItems `json:"items,omitempty"`
+	Enum                 []interface{}            `json:"enum,omitempty"`
+	Min                  *float64                 `json:"minimum,omitempty"`
+	MinItems             *int64                   `json:"minItems,omitempty"`
+	MaxItems             *int64                   `json:"maxItems,omitempty"`
+	AdditionalProperties *JsonResponseSchema      `json:"additionalProperties,omitempty"`
+
+	// Inline, when set, is documented in place of the schema, e.g. the definition
+	// property of an anonymous struct, which keeps the order of its properties.
+	Inline interface{} `json:"-"`
+}
+
+// MarshalJSON documents schemas with an Inline value as that value.
+func (s JsonResponseSchema) MarshalJSON() ([]byte, error) {
+	if s.Inline != nil {
+		return json.Marshal(s.Inline)
+	}
+	type alias JsonResponseSchema
+	return json.Marshal(alias(s))
 }
 
 // JsonResponseSchemeItems represents the individual items in a JsonResponseSchema, especially for arrays.
 // It provides the type or reference for the array items.
 type JsonResponseSchemeItems struct {
-	Type  string                   `json:"type,omitempty"`
-	Ref   string                   `json:"$ref,omitempty"`
-	Items *JsonResponseSchemeItems `json:"items,omitempty"`
+	Type                 string                   `json:"type,omitempty"`
+	Format               string                   `json:"format,omitempty"`
+	Ref                  string                   `json:"$ref,omitempty"`
+	Items                *JsonResponseSchemeItems `json:"items,omitempty"`
+	Enum                 []interface{}            `json:"enum,omitempty"`
+	CollectionFormat     string                   `json:"collectionFormat,omitempty"`
+	Min                  *float64                 `json:"minimum,omitempty"`
+	MinItems             *int64                   `json:"minItems,omitempty"`
+	MaxItems             *int64                   `json:"maxItems,omitempty"`
+	AdditionalProperties *JsonResponseSchema      `json:"additionalProperties,omitempty"`
 }
 
 // Parameter represents a parameter in an API endpoint.
@@ -104,16 +149,23 @@ type Parameter struct {
 	enum             []interface{}
 	defaultValue     interface{}
 	format           string
-	min              int64
-	max              int64
+	items            *JsonResponseSchemeItems
+	min              *float64
+	max              *float64
+	exclusiveMin     bool
+	exclusiveMax     bool
 	minLen           int64
 	maxLen           int64
 	pattern          string
 	maxItems         int64
 	minItems         int64
 	uniqueItems      bool
-	multipleOf       int64
+	multipleOf       *float64
 	collectionFormat CollectionFormat
+	enumType         reflect.Type
+	fieldType        reflect.Type
+	deprecated       bool
+	example          interface{}
 }
 
 // Location returns the location of the parameter (i.e. Query, Body, Path, and etc.)
@@ -121,8 +173,26 @@ func (p Parameter) Location() Location {
 	return p.in
 }
 
-// AsJson returns the json representation of Parameter
+// EnumType returns the named type the allowed values of an EnumParam, or of the items
+// of an array parameter created by ParamsFrom, are resolved from, or nil for other
+// parameters.
+func (p Parameter) EnumType() reflect.Type {
+	return p.enumType
+}
+
+// FieldType returns the type of the struct field a parameter created by ParamsFrom is
+// bound to, or nil for other parameters.
+func (p Parameter) FieldType() reflect.Type {
+	return p.fieldType
+}
+
+// AsJson returns the json representation of Parameter. Array parameters without items
+// get string items, since Swagger 2.0 requires them.
 func (p *Parameter) AsJson() JsonParameter {
+	items := p.items
+	if p.typeValue == Array && items == nil {
+		items = &JsonResponseSchemeItems{Type: String.String()}
+	}
 	return JsonParameter{
 		Name:              p.name,
 		In:                p.in.String(),
@@ -130,10 +200,13 @@ func (p *Parameter) AsJson() JsonParameter {
 		Required:          p.required,
 		Type:              p.typeValue.String(),
 		Format:            p.format,
+		Items:             items,
 		Enum:              p.enum,
 		Default:           p.defaultValue,
 		Min:               p.min,
 		Max:               p.max,
+		ExclusiveMin:      p.exclusiveMin,
+		ExclusiveMax:      p.exclusiveMax,
 		MinLen:            p.minLen,
 		MaxLen:            p.maxLen,
 		Pattern:           p.pattern,
@@ -142,6 +215,8 @@ func (p *Parameter) AsJson() JsonParameter {
 		UniqueItems:       p.uniqueItems,
 		MultipleOf:        p.multipleOf,
 		CollenctionFormat: p.collectionFormat.String(),
+		XDeprecated:       p.deprecated,
+		XExample:          p.example,
 	}
 }
 
@@ -160,6 +235,12 @@ func IntParam(name string, l Location, opts ...Option) *Parameter {
 	return newParam(name, opts...)
 }
 
+// NumberParam creates a number parameter, for floating-point values.
+func NumberParam(name string, l Location, opts ...Option) *Parameter {
+	opts = append(opts, WithType(Number), WithIn(l))
+	return newParam(name, opts...)
+}
+
 // StrParam creates a string parameter.
 func StrParam(name string, l Location, opts ...Option) *Parameter {
 	opts = append(opts, WithType(String), WithIn(l))
@@ -194,9 +275,9 @@ func IntEnumParam(name string, l Location, arr []int64, opts ...Option) *Paramet
 	return param
 }
 
-// StrEnumParam creates a string enum parameter.
-func StrEnumParam(name string, l Location, arr []string, opts ...Option) *Parameter {
-	opts = append(opts, WithType(String), WithIn(l))
+// FloatEnumParam creates a number enum parameter.
+func FloatEnumParam(name string, l Location, arr []float64, opts ...Option) *Parameter {
+	opts = append(opts, WithType(Number), WithIn(l))
 	param := newParam(name, opts...)
 
 	if len(arr) > 0 {
@@ -210,9 +291,9 @@ func StrEnumParam(name string, l Location, arr []string, opts ...Option) *Parame
 	return param
 }
 
-// IntArrParam creates an integer array parameter.
-func IntArrParam(name string, l Location, arr []int64, opts ...Option) *Parameter {
-	opts = append(opts, WithType(Array), WithIn(l))
+// StrEnumParam creates a string enum parameter.
+func StrEnumParam(name string, l Location, arr []string, opts ...Option) *Parameter {
+	opts = append(opts, WithType(String), WithIn(l))
 	param := newParam(name, opts...)
 
 	if len(arr) > 0 {
@@ -226,20 +307,64 @@ func IntArrParam(name string, l Location, arr []int64, opts ...Option) *Paramete
 	return param
 }
 
-// StrArrParam creates a string array parameter.
-func StrArrParam(name string, l Location, arr []string, opts ...Option) *Parameter {
-	opts = append(opts, WithType(Array), WithIn(Location(l)))
+// EnumParam creates a parameter for a named type with a fixed set of values,
+// e.g. EnumParam("status", Query, models.OrderStatus("")). The parameter type follows
+// the kind of value, numeric kinds also set the format unless WithFormat is given and
+// unsigned kinds have a minimum of 0 unless WithMinimum is given. The allowed values come from its Enum method, or from the enums registered on the
+// config when the documentation is generated.
+func EnumParam(name string, l Location, value interface{}, opts ...Option) *Parameter {
+	t := reflect.TypeOf(value)
+	opts = append([]Option{WithFormat(fields.Format(t.Kind()))}, opts...)
+	if min := fields.Minimum(t.Kind()); min != nil {
+		opts = append([]Option{WithMinimum(*min)}, opts...)
+	}
+	opts = append(opts, WithType(ParamType(fields.Type(t.Kind().String()))), WithIn(l))
 	param := newParam(name, opts...)
+	param.enumType = t
+
+	if enum, ok := fields.Enums(nil).Lookup(t); ok {
+		param.enum = enum.Values
+	}
+
+	return param
+}
 
+// IntArrParam creates an integer array parameter. The values of arr, if any, are the
+// allowed values of its items.
+func IntArrParam(name string, l Location, arr []int64, opts ...Option) *Parameter {
+	items := &JsonResponseSchemeItems{Type: Integer.String()}
 	if len(arr) > 0 {
-		s := make([]interface{}, len(arr))
+		items.Enum = make([]interface{}, len(arr))
 		for i, v := range arr {
-			s[i] = v
+			items.Enum[i] = v
 		}
-		param.enum = s
 	}
+	opts = append([]Option{WithItems(items)}, opts...)
+	opts = append(opts, WithType(Array), WithIn(l))
+	return newParam(name, opts...)
+}
 
-	return param
+// StrArrParam creates a string array parameter. The values of arr, if any, are the
+// allowed values of its items.
+func StrArrParam(name string, l Location, arr []string, opts ...Option) *Parameter {
+	items := &JsonResponseSchemeItems{Type: String.String()}
+	if len(arr) > 0 {
+		items.Enum = make([]interface{}, len(arr))
+		for i, v := range arr {
+			items.Enum[i] = v
+		}
+	}
+	opts = append([]Option{WithItems(items)}, opts...)
+	opts = append(opts, WithType(Array), WithIn(l))
+	return newParam(name, opts...)
+}
+
+// ArrParam creates an array parameter whose items are described by items, e.g. for
+// arrays of numbers, enums or, with nested items, arrays of arrays.
+func ArrParam(name string, l Location, items *JsonResponseSchemeItems, opts ...Option) *Parameter {
+	opts = append([]Option{WithItems(items)}, opts...)
+	opts = append(opts, WithType(Array), WithIn(l))
+	return newParam(name, opts...)
 }
 
 // Option represents a function that can modify a Parameter.
@@ -273,6 +398,37 @@ func WithDescription(description string) Option {
 	}
 }
 
+// WithEnum sets the Enum field of a Parameter.
+func WithEnum(values ...interface{}) Option {
+	return func(p *Parameter) {
+		p.enum = values
+	}
+}
+
+// WithItemsEnum sets the allowed values of the items of an array parameter, or of the
+// innermost items of nested arrays. The items are copied, so items shared with other
+// parameters are left unchanged.
+func WithItemsEnum(values ...interface{}) Option {
+	return func(p *Parameter) {
+		items := p.items
+		if items == nil {
+			items = &JsonResponseSchemeItems{Type: String.String()}
+		}
+		p.items = itemsWithEnum(items, values)
+	}
+}
+
+// itemsWithEnum returns a copy of items whose innermost items have the allowed values.
+func itemsWithEnum(items *JsonResponseSchemeItems, values []interface{}) *JsonResponseSchemeItems {
+	withEnum := *items
+	if withEnum.Items != nil {
+		withEnum.Items = itemsWithEnum(withEnum.Items, values)
+	} else {
+		withEnum.Enum = values
+	}
+	return &withEnum
+}
+
 // WithDefault sets the Default field of a Parameter.
 func WithDefault(defaultValue interface{}) Option {
 	return func(p *Parameter) {
@@ -287,20 +443,60 @@ func WithFormat(format string) Option {
 	}
 }
 
-// WithMin sets the Min field of a Parameter.
+// WithMin sets the Min field of a Parameter. A zero min leaves the minimum as it is,
+// use WithMinimum for a minimum of 0.
 func WithMin(min int64) Option {
 	return func(p *Parameter) {
-		p.min = min
+		if min != 0 {
+			p.min, p.exclusiveMin = intBound(min), false
+		}
 	}
 }
 
-// WithMax sets the Max field of a Parameter.
+// WithMax sets the Max field of a Parameter. A zero max leaves the maximum as it is,
+// use WithMaximum for a maximum of 0.
 func WithMax(max int64) Option {
 	return func(p *Parameter) {
-		p.max = max
+		if max != 0 {
+			p.max, p.exclusiveMax = intBound(max), false
+		}
+	}
+}
+
+// WithMinimum sets the inclusive minimum of a Parameter, which may be fractional or 0.
+func WithMinimum(min float64) Option {
+	return func(p *Parameter) {
+		p.min, p.exclusiveMin = &min, false
+	}
+}
+
+// WithMaximum sets the inclusive maximum of a Parameter, which may be fractional or 0.
+func WithMaximum(max float64) Option {
+	return func(p *Parameter) {
+		p.max, p.exclusiveMax = &max, false
 	}
 }
 
+// WithExclusiveMinimum sets a minimum of a Parameter that values must be greater than.
+func WithExclusiveMinimum(min float64) Option {
+	return func(p *Parameter) {
+		p.min, p.exclusiveMin = &min, true
+	}
+}
+
+// WithExclusiveMaximum sets a maximum of a Parameter that values must be less than.
+func WithExclusiveMaximum(max float64) Option {
+	return func(p *Parameter) {
+		p.max, p.exclusiveMax = &max, true
+	}
+}
+
+// intBound returns the bound set by an integer option.
+func intBound(bound int64) *float64 {
+	value := float64(bound)
+	return &value
+}
+
 // WithMinLen sets the MinLen field of a Parameter.
 func WithMinLen(minLen int64) Option {
 	return func(p *Parameter) {
@@ -343,10 +539,28 @@ func WithUniqueItems(uniqueItems bool) Option {
 	}
 }
 
-// WithMultipleOf sets the MultipleOf field of a Parameter.
+// WithMultipleOf sets the MultipleOf field of a Parameter. A zero multipleOf leaves
+// it as it is.
 func WithMultipleOf(multipleOf int64) Option {
 	return func(p *Parameter) {
-		p.multipleOf = multipleOf
+		if multipleOf != 0 {
+			p.multipleOf = intBound(multipleOf)
+		}
+	}
+}
+
+// WithMultipleOfNumber sets the MultipleOf field of a Parameter to a number, which
+// may be fractional, e.g. 0.01 for amounts in cents.
+func WithMultipleOfNumber(multipleOf float64) Option {
+	return func(p *Parameter) {
+		p.multipleOf = &multipleOf
+	}
+}
+
+// WithItems sets the items of an array Parameter.
+func WithItems(items *JsonResponseSchemeItems) Option {
+	return func(p *Parameter) {
+		p.items = items
 	}
 }
 
@@ -357,6 +571,43 @@ func WithCollectionFormat(c CollectionFormat) Option {
 	}
 }
 
+// WithDeprecated marks the parameter as deprecated, with the 'x-deprecated' extension
+// since Swagger 2.0 parameters cannot be deprecated.
+func WithDeprecated() Option {
+	return func(p *Parameter) {
+		p.deprecated = true
+	}
+}
+
+// WithExample sets an example value for the parameter, with the 'x-example' extension
+// since Swagger 2.0 parameters have no examples.
+func WithExample(example interface{}) Option {
+	return func(p *Parameter) {
+		p.example = example
+	}
+}
+
+// WithTypes documents a parameter created by ParamsFrom whose field type, or the type
+// of its items, is registered in types with the registered schema, e.g. a uuid.UUID
+// field as a string instead of an array of bytes.
+func WithTypes(types fields.Types) Option {
+	return func(p *Parameter) {
+		if p.fieldType == nil {
+			return
+		}
+		if schema, ok := types.Lookup(p.fieldType); ok {
+			if p.typeValue == Array {
+				p.items, p.collectionFormat = nil, ""
+			}
+			p.typeValue, p.format = ParamType(schema.Type), schema.Format
+			return
+		}
+		if p.typeValue == Array {
+			p.items = paramItems(p.fieldType.Elem(), types)
+		}
+	}
+}
+
 // newParam creates a newParam parameter with the given options.
 func newParam(name string, opts ...Option) *Parameter {
 	parameter := Parameter{name: name}
@@ -372,11 +623,11 @@ func newParam(name string, opts ...Option) *Parameter {
 // generateParamDescription generates the description for a parameter based on its properties.
 func generateParamDescription(param *Parameter) {
 	newDescription := ""
-	if param.min != 0 {
-		newDescription += "min: " + fmt.Sprint(param.min) + " "
+	if param.min != nil {
+		newDescription += boundLabel("min", param.exclusiveMin) + fmt.Sprint(*param.min) + " "
 	}
-	if param.max != 0 {
-		newDescription += "max: " + fmt.Sprint(param.max) + " "
+	if param.max != nil {
+		newDescription += boundLabel("max", param.exclusiveMax) + fmt.Sprint(*param.max) + " "
 	}
 	if param.minLen != 0 {
 		newDescription += "minLength: " + fmt.Sprint(param.minLen) + " "
@@ -394,3 +645,11 @@ func generateParamDescription(param *Parameter) {
 		param.description += " (" + strings.Trim(newDescription, " ") + ")"
 	}
 }
+
+// boundLabel returns the label of a minimum or maximum in the generated description.
+func boundLabel(bound string, exclusive bool) string {
+	if exclusive {
+		return "exclusive " + bound + ": "
+	}
+	return bound + ": "
+}
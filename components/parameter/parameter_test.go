@@ -1,7 +1,9 @@
 package parameter
 
 import (
+	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -19,6 +21,10 @@ func SliceEqual(a, b []interface{}) bool {
 	return true
 }
 
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
 func ParametersEqual(a, b Parameter) bool {
 	return a.name == b.name &&
 		a.typeValue == b.typeValue &&
@@ -28,15 +34,18 @@ func ParametersEqual(a, b Parameter) bool {
 		//SliceEqual(a.enum, b.enum) &&    // TODO need to update testing to account for int64 vs int mismatch
 		reflect.DeepEqual(a.defaultValue, b.defaultValue) &&
 		a.format == b.format &&
-		a.min == b.min &&
-		a.max == b.max &&
+		reflect.DeepEqual(a.items, b.items) &&
+		reflect.DeepEqual(a.min, b.min) &&
+		reflect.DeepEqual(a.max, b.max) &&
+		a.exclusiveMin == b.exclusiveMin &&
+		a.exclusiveMax == b.exclusiveMax &&
 		a.minLen == b.minLen &&
 		a.maxLen == b.maxLen &&
 		a.pattern == b.pattern &&
 		a.maxItems == b.maxItems &&
 		a.minItems == b.minItems &&
 		a.uniqueItems == b.uniqueItems &&
-		a.multipleOf == b.multipleOf &&
+		reflect.DeepEqual(a.multipleOf, b.multipleOf) &&
 		a.collectionFormat == b.collectionFormat
 }
 
@@ -60,8 +69,7 @@ func TestParams(t *testing.T) {
 				in:          Path,
 				required:    true,
 				description: "A test parameter for path\n (max: 100)",
-				min:         0,
-				max:         100,
+				max:         floatPtr(100),
 			},
 		},
 		{
@@ -75,8 +83,7 @@ func TestParams(t *testing.T) {
 				in:          Query,
 				required:    true,
 				description: "A test parameter for query\n (max: 100)",
-				min:         0,
-				max:         100,
+				max:         floatPtr(100),
 			},
 		},
 		{
@@ -90,8 +97,7 @@ func TestParams(t *testing.T) {
 				in:          Header,
 				required:    true,
 				description: "A test parameter for header\n (max: 100)",
-				min:         0,
-				max:         100,
+				max:         floatPtr(100),
 			},
 		},
 		{
@@ -105,8 +111,7 @@ func TestParams(t *testing.T) {
 				in:          Form,
 				required:    true,
 				description: "A test parameter for form data\n (max: 100)",
-				min:         0,
-				max:         100,
+				max:         floatPtr(100),
 			},
 		},
 	}
@@ -494,7 +499,7 @@ func TestIntArrParam(t *testing.T) {
 				in:          Path,
 				description: " (maxLength: 50)",
 				required:    true,
-				enum:        []interface{}{1, 2, 3},
+				items:       &JsonResponseSchemeItems{Type: "integer", Enum: []interface{}{int64(1), int64(2), int64(3)}},
 				minLen:      0,
 				maxLen:      50,
 			},
@@ -510,7 +515,7 @@ func TestIntArrParam(t *testing.T) {
 				in:          Query,
 				description: " (maxLength: 50)",
 				required:    true,
-				enum:        []interface{}{1, 2, 3},
+				items:       &JsonResponseSchemeItems{Type: "integer", Enum: []interface{}{int64(1), int64(2), int64(3)}},
 				minLen:      0,
 				maxLen:      50,
 			},
@@ -526,7 +531,7 @@ func TestIntArrParam(t *testing.T) {
 				in:          Header,
 				description: " (maxLength: 50)",
 				required:    true,
-				enum:        []interface{}{1, 2, 3},
+				items:       &JsonResponseSchemeItems{Type: "integer", Enum: []interface{}{int64(1), int64(2), int64(3)}},
 				minLen:      0,
 				maxLen:      50,
 			},
@@ -542,7 +547,7 @@ func TestIntArrParam(t *testing.T) {
 				in:          Form,
 				description: " (maxLength: 50)",
 				required:    true,
-				enum:        []interface{}{1, 2, 3},
+				items:       &JsonResponseSchemeItems{Type: "integer", Enum: []interface{}{int64(1), int64(2), int64(3)}},
 				minLen:      0,
 				maxLen:      50,
 			},
@@ -577,7 +582,7 @@ func TestStrArrParam(t *testing.T) {
 				in:          Path,
 				required:    true,
 				description: " (maxLength: 50)",
-				enum:        []interface{}{"a", "b", "c"},
+				items:       &JsonResponseSchemeItems{Type: "string", Enum: []interface{}{"a", "b", "c"}},
 				minLen:      0,
 				maxLen:      50,
 			},
@@ -591,7 +596,7 @@ func TestStrArrParam(t *testing.T) {
 				in:          Query,
 				required:    true,
 				description: " (maxLength: 50)",
-				enum:        []interface{}{"a", "b", "c"},
+				items:       &JsonResponseSchemeItems{Type: "string", Enum: []interface{}{"a", "b", "c"}},
 				minLen:      0,
 				maxLen:      50,
 			},
@@ -605,7 +610,7 @@ func TestStrArrParam(t *testing.T) {
 				in:          Header,
 				required:    true,
 				description: " (maxLength: 50)",
-				enum:        []interface{}{"a", "b", "c"},
+				items:       &JsonResponseSchemeItems{Type: "string", Enum: []interface{}{"a", "b", "c"}},
 				minLen:      0,
 				maxLen:      50,
 			},
@@ -619,7 +624,7 @@ func TestStrArrParam(t *testing.T) {
 				in:          Form,
 				required:    true,
 				description: " (maxLength: 50)",
-				enum:        []interface{}{"a", "b", "c"},
+				items:       &JsonResponseSchemeItems{Type: "string", Enum: []interface{}{"a", "b", "c"}},
 				minLen:      0,
 				maxLen:      50,
 			},
@@ -635,3 +640,146 @@ func TestStrArrParam(t *testing.T) {
 		})
 	}
 }
+
+func TestNumberParam(t *testing.T) {
+	testCases := []struct {
+		name string
+		got  *Parameter
+		want Parameter
+	}{
+		{
+			name: "FractionalBounds",
+			got:  NumberParam("price", Query, WithMinimum(0.01), WithMaximum(99.5), WithMultipleOfNumber(0.01)),
+			want: Parameter{
+				name:        "price",
+				typeValue:   Number,
+				in:          Query,
+				description: " (min: 0.01 max: 99.5)",
+				min:         floatPtr(0.01),
+				max:         floatPtr(99.5),
+				multipleOf:  floatPtr(0.01),
+			},
+		},
+		{
+			name: "ZeroBounds",
+			got:  NumberParam("offset", Query, WithMinimum(0), WithMaximum(0)),
+			want: Parameter{
+				name:        "offset",
+				typeValue:   Number,
+				in:          Query,
+				description: " (min: 0 max: 0)",
+				min:         floatPtr(0),
+				max:         floatPtr(0),
+			},
+		},
+		{
+			name: "ExclusiveBounds",
+			got:  NumberParam("ratio", Query, WithExclusiveMinimum(0), WithExclusiveMaximum(1)),
+			want: Parameter{
+				name:         "ratio",
+				typeValue:    Number,
+				in:           Query,
+				description:  " (exclusive min: 0 exclusive max: 1)",
+				min:          floatPtr(0),
+				max:          floatPtr(1),
+				exclusiveMin: true,
+				exclusiveMax: true,
+			},
+		},
+		{
+			name: "IntBoundsOverride",
+			got:  IntParam("page", Query, WithExclusiveMinimum(0), WithMin(1), WithMax(0)),
+			want: Parameter{
+				name:        "page",
+				typeValue:   Integer,
+				in:          Query,
+				description: " (min: 1)",
+				min:         floatPtr(1),
+			},
+		},
+		{
+			name: "ZeroIntBoundsKeepValues",
+			got:  NumberParam("ratio", Query, WithExclusiveMinimum(0), WithMaximum(2.5), WithMultipleOfNumber(0.5), WithMin(0), WithMax(0), WithMultipleOf(0)),
+			want: Parameter{
+				name:         "ratio",
+				typeValue:    Number,
+				in:           Query,
+				description:  " (exclusive min: 0 max: 2.5)",
+				min:          floatPtr(0),
+				max:          floatPtr(2.5),
+				exclusiveMin: true,
+				multipleOf:   floatPtr(0.5),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if !ParametersEqual(*tc.got, tc.want) {
+				t.Errorf("got = %v, want %v", tc.got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFloatEnumParam(t *testing.T) {
+	got := FloatEnumParam("scale", Query, []float64{0.5, 1, 2}, WithRequired())
+	want := Parameter{
+		name:      "scale",
+		typeValue: Number,
+		in:        Query,
+		required:  true,
+		enum:      []interface{}{0.5, 1.0, 2.0},
+	}
+	if !ParametersEqual(*got, want) || !SliceEqual(got.enum, want.enum) {
+		t.Errorf("got = %v, want %v", got, want)
+	}
+}
+
+func TestExplicitBoundsJson(t *testing.T) {
+	data, err := json.Marshal(NumberParam("ratio", Query, WithExclusiveMinimum(0)).AsJson())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"minimum":0`, `"exclusiveMinimum":true`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s does not contain %s", data, want)
+		}
+	}
+}
+
+type level uint8
+
+func TestEnumParamUnsignedMinimum(t *testing.T) {
+	got := EnumParam("level", Query, level(0))
+	want := Parameter{
+		name:        "level",
+		typeValue:   Integer,
+		in:          Query,
+		format:      "int32",
+		description: " (min: 0)",
+		min:         floatPtr(0),
+	}
+	if !ParametersEqual(*got, want) {
+		t.Errorf("got = %v, want %v", got, want)
+	}
+
+	got = EnumParam("level", Query, level(0), WithMinimum(1))
+	if got.min == nil || *got.min != 1 {
+		t.Errorf("WithMinimum did not override the unsigned minimum: %v", got.min)
+	}
+}
+
+func TestArrayParamItems(t *testing.T) {
+	param := newParam("ids", WithType(Array), WithIn(Query))
+	want := &JsonResponseSchemeItems{Type: "string"}
+	if got := param.AsJson().Items; !reflect.DeepEqual(got, want) {
+		t.Errorf("got items = %v, want %v", got, want)
+	}
+
+	param = IntArrParam("ids", Query, nil, WithItems(&JsonResponseSchemeItems{Type: "integer", Format: "int32"}))
+	want = &JsonResponseSchemeItems{Type: "integer", Format: "int32"}
+	if got := param.AsJson().Items; !reflect.DeepEqual(got, want) || param.AsJson().Enum != nil {
+		t.Errorf("got items = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,241 @@
+package parameter
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-swagno/swagno/components/fields"
+)
+
+// locationTags are the binding struct tags naming the request parameter of a field,
+// in order of precedence, with the location of that parameter. The 'form' tag binds
+// the query string of requests without a form body, as in gin.
+var locationTags = []struct {
+	tag string
+	in  Location
+}{
+	{"uri", Path},
+	{"header", Header},
+	{"query", Query},
+	{"form", Query},
+}
+
+// ParamsFrom reflects the fields of a struct into parameters, e.g.
+// ParamsFrom(ListOrdersRequest{}), so the struct handlers bind requests into documents
+// them as well. Each field with a 'uri', 'header', 'query' or 'form' tag becomes a path,
+// header or query parameter named by the tag. Fields of embedded structs without such
+// a tag are included, other fields are skipped.
+//
+// The parameter type and format follow the field's type, slices become arrays with
+// items and named types with an Enum method, or registered on the config, get their
+// values. Types registered on the config are applied when the documentation is
+// generated. Map fields and struct fields other than known types like time.Time are
+// skipped, since parameters cannot be objects. Constraints and 'required' come from the 'validate' and 'binding' tags, and
+// the 'required', 'default', 'format', 'pattern', 'deprecated', 'example' and 'desc'
+// tags are read like for definition properties. Path parameters are always required.
+func ParamsFrom(value interface{}) []*Parameter {
+	t := reflect.TypeOf(value)
+	if t == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return structParams(t)
+}
+
+func structParams(t reflect.Type) []*Parameter {
+	params := []*Parameter{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, in, ok := paramLocation(field)
+		if !ok {
+			if embedded := field.Type; field.Anonymous {
+				if embedded.Kind() == reflect.Pointer {
+					embedded = embedded.Elem()
+				}
+				if embedded.Kind() == reflect.Struct {
+					params = append(params, structParams(embedded)...)
+				}
+			}
+			continue
+		}
+		if !field.IsExported() || name == "-" || !paramType(field.Type) {
+			continue
+		}
+		params = append(params, fieldParam(field, name, in))
+	}
+	return params
+}
+
+// paramType reports whether fields of type t can be documented as parameters, which
+// excludes maps and structs other than known types since parameters cannot be objects.
+func paramType(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if _, ok := fields.Types(nil).Lookup(t); ok {
+		return true
+	}
+	return t.Kind() != reflect.Map && t.Kind() != reflect.Struct
+}
+
+// paramLocation returns the name and location of the parameter bound to field.
+func paramLocation(field reflect.StructField) (string, Location, bool) {
+	for _, lt := range locationTags {
+		if tag, ok := field.Tag.Lookup(lt.tag); ok {
+			name, _, _ := strings.Cut(tag, ",")
+			if name == "" {
+				name = field.Name
+			}
+			return name, lt.in, true
+		}
+	}
+	return "", "", false
+}
+
+// fieldParam returns the parameter named name at location in bound to field.
+func fieldParam(field reflect.StructField, name string, in Location) *Parameter {
+	t := field.Type
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	opts := append(typeOptions(t, nil), WithIn(in), WithDescription(fields.DescriptionTag(field)), withCollectionFormat(field, in))
+	if in == Path || fields.IsRequired(field) {
+		opts = append(opts, WithRequired())
+	}
+
+	c := fields.Validation(field)
+	if c.Minimum != nil {
+		opts = append(opts, boundOption(*c.Minimum, c.ExclusiveMinimum, WithMinimum, WithExclusiveMinimum))
+	}
+	if c.Maximum != nil {
+		opts = append(opts, boundOption(*c.Maximum, c.ExclusiveMaximum, WithMaximum, WithExclusiveMaximum))
+	}
+	if c.MinLength != nil {
+		opts = append(opts, WithMinLen(*c.MinLength))
+	}
+	if c.MaxLength != nil {
+		opts = append(opts, WithMaxLen(*c.MaxLength))
+	}
+	if c.MinItems != nil {
+		opts = append(opts, WithMinItems(*c.MinItems))
+	}
+	if c.MaxItems != nil {
+		opts = append(opts, WithMaxItems(*c.MaxItems))
+	}
+	if c.UniqueItems {
+		opts = append(opts, WithUniqueItems(true))
+	}
+	if c.Pattern != "" {
+		opts = append(opts, WithPattern(c.Pattern))
+	}
+	if c.Format != "" {
+		opts = append(opts, WithFormat(c.Format))
+	}
+	if c.Enum != nil {
+		opts = append(opts, WithEnum(c.Enum...))
+	}
+
+	a := fields.Documentation(field)
+	if a.Default != nil {
+		opts = append(opts, WithDefault(a.Default))
+	}
+	if a.Format != "" {
+		opts = append(opts, WithFormat(a.Format))
+	}
+	if a.Pattern != "" {
+		opts = append(opts, WithPattern(a.Pattern))
+	}
+	if a.Deprecated {
+		opts = append(opts, WithDeprecated())
+	}
+	if example := fields.ExampleTag(field); example != nil {
+		opts = append(opts, WithExample(example))
+	}
+
+	param := newParam(name, opts...)
+	param.fieldType = t
+	return param
+}
+
+// withCollectionFormat sets the collection format of array parameters from the
+// 'collection_format' tag gin reads, e.g. collection_format:"csv", defaulting to multi
+// for query parameters since binders read their repeated keys.
+func withCollectionFormat(field reflect.StructField, in Location) Option {
+	return func(p *Parameter) {
+		if p.typeValue != Array {
+			return
+		}
+		if format, ok := field.Tag.Lookup("collection_format"); ok {
+			p.collectionFormat = CollectionFormat(format)
+		} else if in == Query {
+			p.collectionFormat = Multi
+		}
+	}
+}
+
+// boundOption returns the option setting a bound, exclusive or not.
+func boundOption(bound float64, exclusive bool, inclusiveOption, exclusiveOption func(float64) Option) Option {
+	if exclusive {
+		return exclusiveOption(bound)
+	}
+	return inclusiveOption(bound)
+}
+
+// typeOptions returns the options documenting the type t of a parameter, resolved
+// through types.
+func typeOptions(t reflect.Type, types fields.Types) []Option {
+	if schema, ok := types.Lookup(t); ok {
+		return []Option{WithType(ParamType(schema.Type)), WithFormat(schema.Format)}
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		items := paramItems(t.Elem(), types)
+		elem := t.Elem()
+		for elem.Kind() == reflect.Pointer || elem.Kind() == reflect.Slice || elem.Kind() == reflect.Array {
+			elem = elem.Elem()
+		}
+		return []Option{WithType(Array), WithItems(items), func(p *Parameter) { p.enumType = elem }}
+	}
+
+	opts := []Option{WithType(ParamType(fields.Type(t.Kind().String()))), WithFormat(fields.Format(t.Kind()))}
+	if min := fields.Minimum(t.Kind()); min != nil {
+		opts = append(opts, WithMinimum(*min))
+	}
+	if t.Name() != "" {
+		opts = append(opts, func(p *Parameter) { p.enumType = t })
+		if enum, ok := fields.Enums(nil).Lookup(t); ok {
+			opts = append(opts, WithEnum(enum.Values...))
+		}
+	}
+	return opts
+}
+
+// paramItems returns the items of array parameters whose elements are of type t,
+// resolved through types.
+func paramItems(t reflect.Type, types fields.Types) *JsonResponseSchemeItems {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if schema, ok := types.Lookup(t); ok {
+		return &JsonResponseSchemeItems{Type: schema.Type, Format: schema.Format}
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return &JsonResponseSchemeItems{Type: Array.String(), Items: paramItems(t.Elem(), types)}
+	}
+	items := &JsonResponseSchemeItems{
+		Type:   fields.Type(t.Kind().String()),
+		Format: fields.Format(t.Kind()),
+		Min:    fields.Minimum(t.Kind()),
+	}
+	if enum, ok := fields.Enums(nil).Lookup(t); ok {
+		items.Enum = enum.Values
+	}
+	return items
+}
@@ -2,7 +2,9 @@ package swagno
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
+	"reflect"
 	"strings"
 
 	"github.com/go-swagno/swagno/components/definition"
@@ -12,9 +14,7 @@ import (
 	"github.com/go-swagno/swagno/components/parameter"
 )
 
-func appendResponses(sourceResponses map[string]endpoint.JsonResponse, additionalResponses []response.Response, hidePackageName bool) map[string]endpoint.JsonResponse {
-	responseGenerator := response.NewResponseGenerator(hidePackageName)
-
+func appendResponses(sourceResponses map[string]endpoint.JsonResponse, additionalResponses []response.Response, responseGenerator *response.ResponseGenerator) map[string]endpoint.JsonResponse {
 	for _, resp := range additionalResponses {
 		var responseSchema *parameter.JsonResponseSchema
 
@@ -45,10 +45,28 @@ func (s *Swagger) generateSwaggerJson() error {
 		return err
 	}
 
+	// add the registered parameters and responses to the document, they are referenced by name from endpoints
+	// https://swagger.io/specification/v2/#parameters-definitions-object
+	componentGenerator := response.NewResponseGenerator(s.hidePackageName)
+	componentGenerator.Types = s.types
+	componentGenerator.NameStrategy = s.nameStrategy
+	componentGenerator.Enums = s.enums
+	componentGenerator.EnumSchemas = s.enumSchemas
+	componentGenerator.Interfaces = s.interfaces
+	componentGenerator.InlineSchema = s.inlineSchema
+	s.generateComponents(componentGenerator)
+
 	// convert all user EndPoint models to 'path' fields of swagger json
 	// https://swagger.io/specification/v2/#paths-object
+	var pathErrors, refErrors, duplicateErrors []error
+	operations := map[string]string{}
 	for _, e := range s.endpoints {
-		path := e.Path()
+		path := endpoint.PathTemplate(e.Path())
+		if previous, ok := operations[string(e.Method())+" "+path]; ok {
+			duplicateErrors = append(duplicateErrors, fmt.Errorf("%s %s: already documented by %s %s", e.Method(), e.Path(), e.Method(), previous))
+			continue
+		}
+		operations[string(e.Method())+" "+path] = e.Path()
 
 		if s.Paths[path] == nil {
 			s.Paths[path] = make(map[string]endpoint.JsonEndPoint)
@@ -63,23 +81,44 @@ func (s *Swagger) generateSwaggerJson() error {
 			}
 		}
 
+		refParams, errs := s.refParams(e, path)
+		refErrors = append(refErrors, errs...)
+
+		params := e.Params()
+		checked := append(append([]*parameter.Parameter{}, params...), refParams...)
+		if s.autoPathParams {
+			undeclared := undeclaredPathParams(path, checked)
+			params = append(params, undeclared...)
+			checked = append(checked, undeclared...)
+		}
+		pathErrors = append(pathErrors, pathParamErrors(e.Method(), path, checked)...)
+
 		parameters := make([]parameter.JsonParameter, 0)
-		for _, param := range e.Params() {
-			pj := param.AsJson()
-			if pj.In != parameter.Query.String() {
-				pj.Type = ""
+		for _, param := range params {
+			parameters = append(parameters, s.paramJson(param))
+		}
+		for _, name := range e.ParamRefs() {
+			if _, ok := s.parameters[name]; ok {
+				parameters = append(parameters, parameter.JsonParameter{Ref: "#/parameters/" + name})
 			}
-			parameters = append(parameters, param.AsJson())
 		}
 
-		if bjp := e.BodyJsonParameter(s.hidePackageName); bjp != nil {
+		// Creates the schema defintion for all successful return and error objects, and then links them in the responses section
+		responseGenerator := response.NewResponseGenerator(s.hidePackageName)
+		responseGenerator.Types = s.types
+		responseGenerator.NameStrategy = s.nameStrategy
+		responseGenerator.Enums = s.enums
+		responseGenerator.EnumSchemas = s.enumSchemas
+		responseGenerator.Interfaces = s.interfaces
+		responseGenerator.InlineSchema = s.inlineSchema
+
+		if bjp := e.BodyJsonParameterWith(responseGenerator); bjp != nil {
 			parameters = append(parameters, *bjp)
 		}
-
-		// Creates the schema defintion for all successful return and error objects, and then links them in the responses section
 		responses := map[string]endpoint.JsonResponse{}
-		responses = appendResponses(responses, e.SuccessfulReturns(), s.hidePackageName)
-		responses = appendResponses(responses, e.Errors(), s.hidePackageName)
+		responses = appendResponses(responses, e.SuccessfulReturns(), responseGenerator)
+		responses = appendResponses(responses, e.Errors(), responseGenerator)
+		refErrors = append(refErrors, s.appendResponseRefs(responses, e, path)...)
 
 		// add each endpoint to paths field of swagger
 		je := e.AsJson()
@@ -89,6 +128,15 @@ func (s *Swagger) generateSwaggerJson() error {
 		s.Paths[path][method] = je
 	}
 
+	if len(refErrors) > 0 {
+		return &ComponentRefError{Errors: refErrors}
+	}
+	if len(pathErrors) > 0 {
+		return &PathParamError{Errors: pathErrors}
+	}
+	if len(duplicateErrors) > 0 {
+		return &DuplicateOperationError{Errors: duplicateErrors}
+	}
 	return nil
 }
 
@@ -102,7 +150,9 @@ func (s *Swagger) ToJson() (jsonDocs []byte, err error) {
 }
 
 // MustToJson same thing as ToJson except for it doesn't return an error.
-// It panics if a name collision is detected while generating the document.
+// It panics if a name collision, an invalid example with StrictExamples, a reference to an
+// unregistered component, a path parameter mismatch or a duplicate operation is detected while
+// generating the document.
 func (s Swagger) MustToJson() (jsonDocs []byte) {
 	if err := s.generateSwaggerJson(); err != nil {
 		panic(err)
@@ -117,28 +167,89 @@ func (s Swagger) MustToJson() (jsonDocs []byte) {
 }
 
 // generate "definitions" keys from endpoints: https://swagger.io/specification/v2/#definitions-object
-// It returns a *NameCollisionError when HidePackageName causes two distinct types
-// to map to the same stripped definition name.
+// It returns a *NameCollisionError when HidePackageName or the naming of generic types
+// causes two distinct types to map to the same definition name.
+// With StrictExamples, it returns an *ExampleError when example tags cannot be converted
+// to their field type. Otherwise they are logged and reported by ExampleErrors.
 func (s *Swagger) generateSwaggerDefinition() error {
-	// shared across all createDefinition calls so collisions are detected document-wide
+	// shared across all createDefinition calls so collisions and invalid examples are detected document-wide
 	definitionTypeNames := map[string]map[string]struct{}{}
+	exampleErrors := map[string]error{}
 	for _, endpoint := range s.endpoints {
 		if endpoint.Body.Content != nil {
-			s.createDefinition(endpoint.Body.Content, definitionTypeNames)
+			s.createDefinition(endpoint.Body.Content, definitionTypeNames, exampleErrors)
 		}
-		s.createDefinitions(endpoint.SuccessfulReturns(), definitionTypeNames)
-		s.createDefinitions(endpoint.Errors(), definitionTypeNames)
+		s.createDefinitions(endpoint.SuccessfulReturns(), definitionTypeNames, exampleErrors)
+		s.createDefinitions(endpoint.Errors(), definitionTypeNames, exampleErrors)
+	}
+	for _, name := range s.responseNames() {
+		s.createDefinition(s.responses[name], definitionTypeNames, exampleErrors)
 	}
-	return collisionError(definitionTypeNames)
+	if err := collisionError(definitionTypeNames); err != nil {
+		return err
+	}
+	s.exampleErrors = sortedExampleErrors(exampleErrors)
+	if len(s.exampleErrors) == 0 {
+		return nil
+	}
+	err := &ExampleError{Errors: s.exampleErrors}
+	if s.strictExamples {
+		return err
+	}
+	log.Println(err)
+	return nil
 }
 
-func (s *Swagger) createDefinitions(r []response.Response, definitionTypeNames map[string]map[string]struct{}) {
+func (s *Swagger) createDefinitions(r []response.Response, definitionTypeNames map[string]map[string]struct{}, exampleErrors map[string]error) {
 	for _, obj := range r {
-		s.createDefinition(obj, definitionTypeNames)
+		s.createDefinition(obj, definitionTypeNames, exampleErrors)
 	}
 }
 
-func (s *Swagger) createDefinition(t interface{}, definitionTypeNames map[string]map[string]struct{}) {
-	generator := definition.NewDefinitionGenerator((*s).Definitions, s.hidePackageName, definitionTypeNames)
-	generator.CreateDefinition(t)
+func (s *Swagger) createDefinition(t interface{}, definitionTypeNames map[string]map[string]struct{}, exampleErrors map[string]error) {
+	s.definitionGenerator((*s).Definitions, definitionTypeNames, exampleErrors).CreateDefinition(t)
+}
+
+// definitionGenerator returns a definition generator adding the definitions of types to definitions,
+// configured like the document.
+func (s *Swagger) definitionGenerator(definitions map[string]definition.Definition, definitionTypeNames map[string]map[string]struct{}, exampleErrors map[string]error) *definition.DefinitionGenerator {
+	generator := definition.NewDefinitionGenerator(definitions, s.hidePackageName, definitionTypeNames)
+	generator.Types = s.types
+	generator.Enums = s.enums
+	generator.EnumSchemas = s.enumSchemas
+	generator.Interfaces = s.interfaces
+	generator.NameStrategy = s.nameStrategy
+	generator.ExampleErrors = exampleErrors
+	generator.Comments = s.comments
+	return generator
+}
+
+// inlineSchema documents anonymous structs, and collections of them, in place with the
+// definition generator, so anonymous struct bodies and responses are documented like
+// anonymous struct properties. The definitions they reference are created with the document's,
+// those created here are discarded.
+func (s *Swagger) inlineSchema(t reflect.Type) *parameter.JsonResponseSchema {
+	generator := s.definitionGenerator(map[string]definition.Definition{}, map[string]map[string]struct{}{}, nil)
+	property := generator.InlineProperty(t)
+	return &parameter.JsonResponseSchema{Type: property.Type, Inline: property}
+}
+
+// paramJson returns the json representation of param with the types registered on the
+// config applied to its field type, and the allowed values of its enum type registered
+// on the config, which are those of the items of array parameters. param itself is left
+// unchanged, since it may be shared by several endpoints or documents.
+func (s *Swagger) paramJson(param *parameter.Parameter) parameter.JsonParameter {
+	resolved := *param
+	parameter.WithTypes(s.types)(&resolved)
+	pj := resolved.AsJson()
+	enum, ok := s.enums.Lookup(param.EnumType())
+	if !ok {
+		return pj
+	}
+	if pj.Type == parameter.Array.String() {
+		parameter.WithItemsEnum(enum.Values...)(&resolved)
+	} else {
+		parameter.WithEnum(enum.Values...)(&resolved)
+	}
+	return resolved.AsJson()
 }
@@ -1,14 +1,19 @@
 package swagno
 
 import (
+	"database/sql"
 	"encoding/json"
 	"errors"
+	"net"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-swagno/swagno/components/definition"
 	"github.com/go-swagno/swagno/components/endpoint"
+	"github.com/go-swagno/swagno/components/fields"
 	"github.com/go-swagno/swagno/components/http/response"
 	"github.com/go-swagno/swagno/components/mime"
 	"github.com/go-swagno/swagno/components/parameter"
@@ -117,7 +122,7 @@ func TestSwaggerGeneration(t *testing.T) {
 			got.AddEndpoints(tc.endpoints)
 			got.generateSwaggerJson()
 
-			if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(Swagger{}, "endpoints", "hidePackageName"), cmpopts.IgnoreFields(definition.DefinitionProperties{}, "Example", "IsRequired")); diff != "" {
+			if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(Swagger{}, "endpoints", "hidePackageName", "types", "enums", "enumSchemas", "interfaces", "nameStrategy", "comments", "autoPathParams", "strictExamples", "exampleErrors", "parameters", "responses"), cmpopts.IgnoreFields(definition.DefinitionProperties{}, "Example", "IsRequired")); diff != "" {
 				t.Errorf("JsonSwagger() mismatch (-expected +got):\n%s", diff)
 			}
 		})
@@ -251,3 +256,1649 @@ func TestHidePackageNameCollision(t *testing.T) {
 		}
 	})
 }
+
+type validatedModel struct {
+	Name   string    `json:"name" validate:"required,min=1,max=64"`
+	Email  string    `json:"email,omitempty" validate:"omitempty,email"`
+	Age    int       `json:"age" binding:"gte=18,lt=130"`
+	Role   string    `json:"role" validate:"oneof=admin 'power user' guest"`
+	Level  int       `json:"level" validate:"oneof=1 2 3"`
+	Tags   []string  `json:"tags" validate:"min=1,unique,dive,max=10"`
+	Nick   *string   `json:"nick" validate:"required,alphanum"`
+	Ignore string    `json:"ignore" validate:"rgb|rgba"`
+	Active bool      `json:"active" validate:"eq=true"`
+	Since  time.Time `json:"since" validate:"min=1"`
+}
+
+func TestValidateTags(t *testing.T) {
+	sw := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	sw.AddEndpoint(endpoint.New(endpoint.POST, "/validated", endpoint.WithBody(validatedModel{})))
+	if err := sw.generateSwaggerDefinition(); err != nil {
+		t.Fatal(err)
+	}
+
+	def, ok := sw.Definitions["swagno.validatedModel"]
+	if !ok {
+		t.Fatalf("expected definition swagno.validatedModel, got %v", sw.Definitions)
+	}
+	i64 := func(v int64) *int64 { return &v }
+	f64 := func(v float64) *float64 { return &v }
+	want := map[string]definition.DefinitionProperties{
+		"name":   {Type: "string", MinLength: i64(1), MaxLength: i64(64)},
+		"email":  {Type: "string", Format: "email"},
+		"age":    {Type: "integer", Format: "int64", Minimum: f64(18), Maximum: f64(130), ExclusiveMaximum: true},
+		"role":   {Type: "string", Enum: []interface{}{"admin", "power user", "guest"}},
+		"level":  {Type: "integer", Format: "int64", Enum: []interface{}{int64(1), int64(2), int64(3)}},
+		"tags":   {Type: "array", Items: &definition.DefinitionPropertiesItems{Type: "string"}, MinItems: i64(1), UniqueItems: true},
+		"nick":   {Type: "string", Pattern: `^[a-zA-Z0-9]+$`},
+		"ignore": {Type: "string"},
+		"active": {Type: "boolean", Enum: []interface{}{true}},
+		"since":  {Type: "string", Format: "date-time"},
+	}
+	if diff := cmp.Diff(want, propertiesMap(def.Properties), cmpopts.IgnoreFields(definition.DefinitionProperties{}, "Example", "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"name", "age", "role", "level", "tags", "nick", "ignore", "active", "since"}, def.Required); diff != "" {
+		t.Errorf("required mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// uuidLike mimics a third-party UUID type whose Go representation ([16]byte)
+// differs from its JSON representation (a string).
+type uuidLike [16]byte
+
+type registeredTypesModel struct {
+	ID       uuidLike        `json:"id"`
+	ParentID *uuidLike       `json:"parent_id"`
+	Related  []uuidLike      `json:"related"`
+	Avatar   []byte          `json:"avatar"`
+	Raw      json.RawMessage `json:"raw"`
+	Addr     net.IP          `json:"addr"`
+	Nick     sql.NullString  `json:"nick"`
+	Created  time.Time       `json:"created"`
+}
+
+func TestRegisterType(t *testing.T) {
+	cfg := Config{Title: "Testing API", Version: "v1.0.0"}
+	cfg.RegisterType(reflect.TypeOf(uuidLike{}), fields.TypeSchema{Type: "string", Format: "uuid"})
+	sw := New(cfg)
+	sw.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/registered",
+		endpoint.WithSuccessfulReturns([]response.Response{
+			response.New(registeredTypesModel{}, "200", "OK"),
+			response.New([]uuidLike{}, "206", "Partial"),
+		}),
+	))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]definition.DefinitionProperties{
+		"id":        {Type: "string", Format: "uuid"},
+		"parent_id": {Type: "string", Format: "uuid"},
+		"related":   {Type: "array", Items: &definition.DefinitionPropertiesItems{Type: "string", Format: "uuid"}},
+		"avatar":    {Type: "string", Format: "byte"},
+		"raw":       {},
+		"addr":      {Type: "string", Format: "ip"},
+		"nick":      {Type: "string"},
+		"created":   {Type: "string", Format: "date-time"},
+	}
+	got := propertiesMap(sw.Definitions["swagno.registeredTypesModel"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.DefinitionProperties{}, "Example", "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+	if len(sw.Definitions) != 1 {
+		t.Errorf("expected registered types to be documented inline, got definitions %v", sw.Definitions)
+	}
+
+	partial := sw.Paths["/registered"]["get"].Responses["206"].Schema
+	wantPartial := &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "string", Format: "uuid"}}
+	if diff := cmp.Diff(wantPartial, partial); diff != "" {
+		t.Errorf("response schema mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type providedStatus string
+
+func (providedStatus) SwaggerSchema() definition.Definition {
+	return definition.Definition{Type: "string", Enum: []interface{}{"active", "inactive"}}
+}
+
+type providedMoney struct {
+	Units    int64  `json:"units"`
+	Currency string `json:"currency"`
+}
+
+func (*providedMoney) SwaggerSchema() definition.Definition {
+	return definition.Definition{Type: "string", Example: "12.50 EUR"}
+}
+
+type providedModel struct {
+	Status  providedStatus   `json:"status"`
+	Price   *providedMoney   `json:"price"`
+	History []providedStatus `json:"history"`
+}
+
+func TestSchemaProvider(t *testing.T) {
+	sw := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	sw.AddEndpoint(endpoint.New(
+		endpoint.POST,
+		"/provided",
+		endpoint.WithBody(providedModel{}),
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(providedStatus(""), "200", "OK")}),
+	))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	wantDefinitions := map[string]definition.Definition{
+		"swagno.providedStatus": {Type: "string", Enum: []interface{}{"active", "inactive"}},
+		"swagno.providedMoney":  {Type: "string", Example: "12.50 EUR"},
+	}
+	for name, want := range wantDefinitions {
+		if diff := cmp.Diff(want, sw.Definitions[name]); diff != "" {
+			t.Errorf("definition %s mismatch (-want +got):\n%s", name, diff)
+		}
+	}
+
+	want := map[string]definition.DefinitionProperties{
+		"status":  {Ref: "#/definitions/swagno.providedStatus"},
+		"price":   {Ref: "#/definitions/swagno.providedMoney"},
+		"history": {Type: "array", Items: &definition.DefinitionPropertiesItems{Ref: "#/definitions/swagno.providedStatus"}},
+	}
+	got := propertiesMap(sw.Definitions["swagno.providedModel"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.DefinitionProperties{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+
+	schema := sw.Paths["/provided"]["post"].Responses["200"].Schema
+	if schema == nil || schema.Ref != "#/definitions/swagno.providedStatus" {
+		t.Errorf("expected response to reference swagno.providedStatus, got %+v", schema)
+	}
+}
+
+type orderStatus string
+
+const (
+	orderStatusNew  orderStatus = "new"
+	orderStatusPaid orderStatus = "paid"
+)
+
+func (orderStatus) Enum() []interface{} {
+	return []interface{}{orderStatusNew, orderStatusPaid}
+}
+
+func (orderStatus) EnumVarNames() []string {
+	return []string{"orderStatusNew", "orderStatusPaid"}
+}
+
+type orderPriority int
+
+type enumModel struct {
+	Status   orderStatus   `json:"status"`
+	Previous *orderStatus  `json:"previous"`
+	History  []orderStatus `json:"history"`
+	Priority orderPriority `json:"priority"`
+}
+
+func TestEnums(t *testing.T) {
+	newSwagger := func(enumSchemas bool) *Swagger {
+		cfg := Config{Title: "Testing API", Version: "v1.0.0", EnumSchemas: enumSchemas}
+		cfg.RegisterEnum(reflect.TypeOf(orderPriority(0)), fields.Enum{Values: []interface{}{1, 2, 3}})
+		sw := New(cfg)
+		sw.AddEndpoint(endpoint.New(
+			endpoint.POST,
+			"/orders",
+			endpoint.WithParams(
+				parameter.EnumParam("status", parameter.Query, orderStatus("")),
+				parameter.EnumParam("priority", parameter.Query, orderPriority(0)),
+			),
+			endpoint.WithBody(enumModel{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New([]orderStatus{}, "200", "OK"),
+				response.New(orderPriority(0), "201", "Created"),
+			}),
+		))
+		if err := sw.generateSwaggerJson(); err != nil {
+			t.Fatal(err)
+		}
+		return sw
+	}
+	statusValues := []interface{}{orderStatusNew, orderStatusPaid}
+
+	t.Run("inline", func(t *testing.T) {
+		sw := newSwagger(false)
+		want := map[string]definition.DefinitionProperties{
+			"status":   {Type: "string", Enum: statusValues},
+			"previous": {Type: "string", Enum: statusValues},
+			"history":  {Type: "array", Items: &definition.DefinitionPropertiesItems{Type: "string", Enum: statusValues}},
+			"priority": {Type: "integer", Format: "int64", Enum: []interface{}{1, 2, 3}},
+		}
+		got := propertiesMap(sw.Definitions["swagno.enumModel"].Properties)
+		if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.DefinitionProperties{}, "IsRequired")); diff != "" {
+			t.Errorf("properties mismatch (-want +got):\n%s", diff)
+		}
+
+		params := sw.Paths["/orders"]["post"].Parameters
+		if diff := cmp.Diff(statusValues, params[0].Enum); diff != "" || params[0].Type != "string" {
+			t.Errorf("status parameter mismatch: %+v", params[0])
+		}
+		if diff := cmp.Diff([]interface{}{1, 2, 3}, params[1].Enum); diff != "" || params[1].Type != "integer" {
+			t.Errorf("priority parameter mismatch: %+v", params[1])
+		}
+
+		history := sw.Paths["/orders"]["post"].Responses["200"].Schema
+		if diff := cmp.Diff(statusValues, history.Items.Enum); diff != "" || history.Items.Type != "string" {
+			t.Errorf("history response mismatch: %+v", history.Items)
+		}
+		priority := sw.Paths["/orders"]["post"].Responses["201"].Schema
+		if diff := cmp.Diff([]interface{}{1, 2, 3}, priority.Enum); diff != "" || priority.Type != "integer" {
+			t.Errorf("priority response mismatch: %+v", priority)
+		}
+	})
+
+	t.Run("enum schemas", func(t *testing.T) {
+		sw := newSwagger(true)
+		want := map[string]definition.DefinitionProperties{
+			"status":   {Ref: "#/definitions/swagno.orderStatus"},
+			"previous": {Ref: "#/definitions/swagno.orderStatus"},
+			"history":  {Type: "array", Items: &definition.DefinitionPropertiesItems{Ref: "#/definitions/swagno.orderStatus"}},
+			"priority": {Ref: "#/definitions/swagno.orderPriority"},
+		}
+		got := propertiesMap(sw.Definitions["swagno.enumModel"].Properties)
+		if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.DefinitionProperties{}, "IsRequired")); diff != "" {
+			t.Errorf("properties mismatch (-want +got):\n%s", diff)
+		}
+
+		wantStatus := definition.Definition{Type: "string", Enum: statusValues, XEnumVarNames: []string{"orderStatusNew", "orderStatusPaid"}}
+		if diff := cmp.Diff(wantStatus, sw.Definitions["swagno.orderStatus"]); diff != "" {
+			t.Errorf("enum definition mismatch (-want +got):\n%s", diff)
+		}
+
+		if got := sw.Paths["/orders"]["post"].Responses["200"].Schema.Items.Ref; got != "#/definitions/swagno.orderStatus" {
+			t.Errorf("expected history response items to reference the enum, got %q", got)
+		}
+		if got := sw.Paths["/orders"]["post"].Responses["201"].Schema.Ref; got != "#/definitions/swagno.orderPriority" {
+			t.Errorf("expected priority response to reference the enum, got %q", got)
+		}
+	})
+}
+
+func TestSharedEnumParams(t *testing.T) {
+	priority := parameter.EnumParam("priority", parameter.Query, orderPriority(0))
+	generate := func(cfg Config) *Swagger {
+		sw := New(cfg)
+		sw.RegisterParameter("priority", priority)
+		sw.AddEndpoint(endpoint.New(endpoint.GET, "/orders", endpoint.WithParams(priority)))
+		if err := sw.generateSwaggerJson(); err != nil {
+			t.Fatal(err)
+		}
+		return sw
+	}
+
+	withEnums := Config{Title: "Testing API", Version: "v1.0.0"}
+	withEnums.RegisterEnum(reflect.TypeOf(orderPriority(0)), fields.Enum{Values: []interface{}{1, 2, 3}})
+	sw := generate(withEnums)
+	if diff := cmp.Diff([]interface{}{1, 2, 3}, sw.Paths["/orders"]["get"].Parameters[0].Enum); diff != "" {
+		t.Errorf("parameter enum mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]interface{}{1, 2, 3}, sw.Parameters["priority"].Enum); diff != "" {
+		t.Errorf("registered parameter enum mismatch (-want +got):\n%s", diff)
+	}
+
+	sw = generate(Config{Title: "Testing API", Version: "v1.0.0"})
+	if got := sw.Paths["/orders"]["get"].Parameters[0].Enum; got != nil {
+		t.Errorf("expected no parameter enum without registered enums, got %v", got)
+	}
+	if got := sw.Parameters["priority"].Enum; got != nil {
+		t.Errorf("expected no registered parameter enum without registered enums, got %v", got)
+	}
+}
+
+type shape interface {
+	Area() float64
+}
+
+type circle struct {
+	Kind   string  `json:"kind"`
+	Radius float64 `json:"radius"`
+}
+
+func (c circle) Area() float64 { return 3.14 * c.Radius * c.Radius }
+
+type square struct {
+	Kind string  `json:"kind"`
+	Side float64 `json:"side"`
+}
+
+func (s square) Area() float64 { return s.Side * s.Side }
+
+type drawing struct {
+	Main     shape         `json:"main"`
+	Shapes   []shape       `json:"shapes"`
+	Payload  interface{}   `json:"payload"`
+	Extras   []interface{} `json:"extras"`
+	ShapeRef *shape        `json:"shape_ref"`
+	Anything *interface{}  `json:"anything"`
+	OnDraw   *func()       `json:"on_draw"`
+	Events   *chan int     `json:"events"`
+}
+
+func TestInterfaces(t *testing.T) {
+	cfg := Config{Title: "Testing API", Version: "v1.0.0"}
+	cfg.RegisterInterface(reflect.TypeOf((*shape)(nil)).Elem(), fields.Implementations{
+		PropertyName: "kind",
+		Mapping:      map[string]interface{}{"circle": circle{}, "square": square{}},
+	})
+	sw := New(cfg)
+	sw.AddEndpoint(endpoint.New(endpoint.POST, "/drawings", endpoint.WithBody(drawing{})))
+	if err := sw.generateSwaggerDefinition(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]definition.DefinitionProperties{
+		"main":      {Ref: "#/definitions/swagno.shape"},
+		"shapes":    {Type: "array", Items: &definition.DefinitionPropertiesItems{Ref: "#/definitions/swagno.shape"}},
+		"payload":   {},
+		"extras":    {Type: "array", Items: &definition.DefinitionPropertiesItems{}},
+		"shape_ref": {Ref: "#/definitions/swagno.shape"},
+		"anything":  {},
+	}
+	got := propertiesMap(sw.Definitions["swagno.drawing"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.DefinitionProperties{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+
+	wantShape := definition.Definition{
+		Type: "object",
+		Properties: definition.Properties{
+			{Name: "kind", Schema: definition.DefinitionProperties{Type: "string", Enum: []interface{}{"circle", "square"}}},
+		},
+		Required: []string{"kind"},
+		XDiscriminator: &definition.Discriminator{
+			PropertyName: "kind",
+			Mapping: map[string]string{
+				"circle": "#/definitions/swagno.circle",
+				"square": "#/definitions/swagno.square",
+			},
+		},
+	}
+	if diff := cmp.Diff(wantShape, sw.Definitions["swagno.shape"]); diff != "" {
+		t.Errorf("interface definition mismatch (-want +got):\n%s", diff)
+	}
+	for _, name := range []string{"swagno.circle", "swagno.square"} {
+		if _, ok := sw.Definitions[name]; !ok {
+			t.Errorf("expected implementation definition %s", name)
+		}
+	}
+}
+
+type mapItem struct {
+	Name string `json:"name"`
+}
+
+type mapModel struct {
+	Counts   map[string]int          `json:"counts"`
+	Items    map[string]mapItem      `json:"items"`
+	Groups   map[string][]mapItem    `json:"groups"`
+	Batches  []map[string]*mapItem   `json:"batches"`
+	Labels   *map[string]string      `json:"labels"`
+	Metadata map[string]interface{}  `json:"metadata"`
+	Nested   map[string]map[int]bool `json:"nested"`
+}
+
+func TestMaps(t *testing.T) {
+	sw := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	sw.AddEndpoint(endpoint.New(
+		endpoint.POST,
+		"/maps",
+		endpoint.WithBody(map[string][]mapItem{}),
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(mapModel{}, "200", "OK")}),
+		endpoint.WithErrors([]response.Response{response.New(map[string]mapItem{}, "400", "Bad Request")}),
+	))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	itemRef := &definition.DefinitionProperties{Ref: "#/definitions/swagno.mapItem"}
+	want := map[string]definition.DefinitionProperties{
+		"counts":   {Type: "object", AdditionalProperties: &definition.DefinitionProperties{Type: "integer", Format: "int64"}},
+		"items":    {Type: "object", AdditionalProperties: itemRef},
+		"groups":   {Type: "object", AdditionalProperties: &definition.DefinitionProperties{Type: "array", Items: &definition.DefinitionPropertiesItems{Ref: "#/definitions/swagno.mapItem"}}},
+		"batches":  {Type: "array", Items: &definition.DefinitionPropertiesItems{Type: "object", AdditionalProperties: itemRef}},
+		"labels":   {Type: "object", AdditionalProperties: &definition.DefinitionProperties{Type: "string"}},
+		"metadata": {Type: "object", AdditionalProperties: &definition.DefinitionProperties{}},
+		"nested":   {Type: "object", AdditionalProperties: &definition.DefinitionProperties{Type: "object", AdditionalProperties: &definition.DefinitionProperties{Type: "boolean"}}},
+	}
+	got := propertiesMap(sw.Definitions["swagno.mapModel"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.DefinitionProperties{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+
+	var names []string
+	for name := range sw.Definitions {
+		names = append(names, name)
+	}
+	if diff := cmp.Diff([]string{"swagno.mapItem", "swagno.mapModel"}, names, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("definitions mismatch (-want +got):\n%s", diff)
+	}
+
+	operation := sw.Paths["/maps"]["post"]
+	wantBody := &parameter.JsonResponseSchema{
+		Type:                 "object",
+		AdditionalProperties: &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Ref: "#/definitions/swagno.mapItem"}},
+	}
+	if diff := cmp.Diff(wantBody, operation.Parameters[0].Schema); diff != "" {
+		t.Errorf("body schema mismatch (-want +got):\n%s", diff)
+	}
+	wantError := &parameter.JsonResponseSchema{
+		Type:                 "object",
+		AdditionalProperties: &parameter.JsonResponseSchema{Ref: "#/definitions/swagno.mapItem"},
+	}
+	if diff := cmp.Diff(wantError, operation.Responses["400"].Schema); diff != "" {
+		t.Errorf("response schema mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type embeddedAudit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type embeddedBase struct {
+	embeddedAudit
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type embeddedMeta struct {
+	Version int `json:"version"`
+}
+
+type embeddedModel struct {
+	embeddedBase
+	*embeddedMeta
+	Owner embeddedAudit `json:"owner"`
+	Name  string        `json:"name" example:"shadowed"`
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	sw := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	sw.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/embedded",
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(embeddedModel{}, "200", "OK")}),
+	))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := definition.Definition{
+		Type: "object",
+		Properties: definition.Properties{
+			{Name: "created_by", Schema: definition.DefinitionProperties{Type: "string"}},
+			{Name: "id", Schema: definition.DefinitionProperties{Type: "integer", Format: "int64"}},
+			{Name: "version", Schema: definition.DefinitionProperties{Type: "integer", Format: "int64"}},
+			{Name: "owner", Schema: definition.DefinitionProperties{Ref: "#/definitions/swagno.embeddedAudit"}},
+			{Name: "name", Schema: definition.DefinitionProperties{Type: "string", Example: "shadowed"}},
+		},
+		Required: []string{"created_by", "id", "version", "owner", "name"},
+	}
+	got := sw.Definitions["swagno.embeddedModel"]
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.DefinitionProperties{}, "IsRequired")); diff != "" {
+		t.Errorf("definition mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type genericItem struct {
+	Name string `json:"name"`
+}
+
+type genericPage[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+type genericCatalog struct {
+	Featured genericPage[genericItem] `json:"featured"`
+}
+
+func TestGenericTypes(t *testing.T) {
+	newSwagger := func(generic fields.GenericNameFunc) *Swagger {
+		sw := New(Config{Title: "Testing API", Version: "v1.0.0", HidePackageName: true, GenericName: generic})
+		sw.AddEndpoint(endpoint.New(
+			endpoint.POST,
+			"/pages",
+			endpoint.WithBody(genericPage[genericItem]{}),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(genericCatalog{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(genericPage[string]{}, "400", "Bad Request")}),
+		))
+		return sw
+	}
+
+	t.Run("default names", func(t *testing.T) {
+		sw := newSwagger(nil)
+		if err := sw.generateSwaggerJson(); err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for name := range sw.Definitions {
+			names = append(names, name)
+		}
+		want := []string{"genericCatalog", "genericItem", "genericPage_genericItem", "genericPage_string"}
+		if diff := cmp.Diff(want, names, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+			t.Errorf("definitions mismatch (-want +got):\n%s", diff)
+		}
+
+		operation := sw.Paths["/pages"]["post"]
+		if got := operation.Parameters[0].Schema.Ref; got != "#/definitions/genericPage_genericItem" {
+			t.Errorf("unexpected body reference %q", got)
+		}
+		if got := operation.Responses["400"].Schema.Ref; got != "#/definitions/genericPage_string" {
+			t.Errorf("unexpected response reference %q", got)
+		}
+		if got := propertiesMap(sw.Definitions["genericCatalog"].Properties)["featured"].Ref; got != "#/definitions/genericPage_genericItem" {
+			t.Errorf("unexpected property reference %q", got)
+		}
+	})
+
+	t.Run("custom names collide", func(t *testing.T) {
+		_, err := newSwagger(func(name string, typeArgs []string) string {
+			return name + "Page"
+		}).ToJson()
+		var collisionErr *NameCollisionError
+		if !errors.As(err, &collisionErr) {
+			t.Fatalf("expected *NameCollisionError, got %T: %v", err, err)
+		}
+		if _, ok := collisionErr.Collisions["genericPagePage"]; !ok {
+			t.Errorf("expected a collision on genericPagePage, got %v", collisionErr.Collisions)
+		}
+	})
+}
+
+type namedOwner struct {
+	Name string `json:"name"`
+}
+
+type namedModel struct {
+	Owner  namedOwner   `json:"owner"`
+	Owners []namedOwner `json:"owners"`
+}
+
+func TestNameStrategy(t *testing.T) {
+	tests := []struct {
+		name      string
+		strategy  fields.NameStrategy
+		wantModel string
+		wantOwner string
+	}{
+		{"qualified", nil, "swagno.namedModel", "swagno.namedOwner"},
+		{"import path", fields.ImportPathNames(), "github.com.go-swagno.swagno.namedModel", "github.com.go-swagno.swagno.namedOwner"},
+		{"path segments", fields.PathSegmentNames(2), "go-swagno.swagno.namedModel", "go-swagno.swagno.namedOwner"},
+		{"package prefix", fields.PackagePrefixNames(map[string]string{"github.com/go-swagno/swagno": "Api"}), "ApinamedModel", "ApinamedOwner"},
+		{"callback", fields.NameFunc(func(t reflect.Type) string { return strings.ToUpper(t.Name()) }), "NAMEDMODEL", "NAMEDOWNER"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sw := New(Config{Title: "Testing API", Version: "v1.0.0", NameStrategy: tt.strategy})
+			sw.AddEndpoint(endpoint.New(
+				endpoint.POST,
+				"/models",
+				endpoint.WithBody(namedModel{}),
+				endpoint.WithSuccessfulReturns([]response.Response{response.New([]namedModel{}, "200", "OK")}),
+				endpoint.WithErrors([]response.Response{response.New(namedOwner{}, "400", "Bad Request")}),
+			))
+			if err := sw.generateSwaggerJson(); err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+			for name := range sw.Definitions {
+				names = append(names, name)
+			}
+			if diff := cmp.Diff([]string{tt.wantModel, tt.wantOwner}, names, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("definitions mismatch (-want +got):\n%s", diff)
+			}
+
+			operation := sw.Paths["/models"]["post"]
+			refs := map[string]string{
+				"body":     operation.Parameters[0].Schema.Ref,
+				"response": operation.Responses["200"].Schema.Items.Ref,
+				"error":    operation.Responses["400"].Schema.Ref,
+				"property": propertiesMap(sw.Definitions[tt.wantModel].Properties)["owner"].Ref,
+				"items":    propertiesMap(sw.Definitions[tt.wantModel].Properties)["owners"].Items.Ref,
+			}
+			wantRefs := map[string]string{
+				"body":     "#/definitions/" + tt.wantModel,
+				"response": "#/definitions/" + tt.wantModel,
+				"error":    "#/definitions/" + tt.wantOwner,
+				"property": "#/definitions/" + tt.wantOwner,
+				"items":    "#/definitions/" + tt.wantOwner,
+			}
+			if diff := cmp.Diff(wantRefs, refs); diff != "" {
+				t.Errorf("references mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("collision", func(t *testing.T) {
+		sw := New(Config{Title: "Testing API", Version: "v1.0.0", NameStrategy: fields.NameFunc(func(reflect.Type) string { return "Model" })})
+		sw.AddEndpoint(endpoint.New(endpoint.POST, "/models", endpoint.WithBody(namedModel{})))
+
+		_, err := sw.ToJson()
+		var collisionErr *NameCollisionError
+		if !errors.As(err, &collisionErr) {
+			t.Fatalf("expected *NameCollisionError, got %T: %v", err, err)
+		}
+	})
+}
+
+type treeNode struct {
+	Value    string      `json:"value"`
+	Children []*treeNode `json:"children"`
+	Parent   *treeNode   `json:"parent"`
+}
+
+type graphNode struct {
+	ID    int         `json:"id"`
+	Edges []graphEdge `json:"edges"`
+}
+
+type graphEdge struct {
+	From *graphNode           `json:"from"`
+	To   *graphNode           `json:"to"`
+	Next map[string]graphEdge `json:"next"`
+}
+
+type listNode struct {
+	Value int       `json:"value"`
+	Next  *listNode `json:"next"`
+}
+
+func TestRecursiveTypes(t *testing.T) {
+	sw := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	sw.AddEndpoint(endpoint.New(
+		endpoint.POST,
+		"/recursive",
+		endpoint.WithBody(treeNode{}),
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(graphNode{}, "200", "OK")}),
+		endpoint.WithErrors([]response.Response{response.New([]listNode{}, "400", "Bad Request")}),
+	))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	ref := func(name string) string { return "#/definitions/swagno." + name }
+	want := map[string]map[string]definition.DefinitionProperties{
+		"swagno.treeNode": {
+			"value":    {Type: "string"},
+			"children": {Type: "array", Items: &definition.DefinitionPropertiesItems{Ref: ref("treeNode")}},
+			"parent":   {Ref: ref("treeNode")},
+		},
+		"swagno.graphNode": {
+			"id":    {Type: "integer", Format: "int64"},
+			"edges": {Type: "array", Items: &definition.DefinitionPropertiesItems{Ref: ref("graphEdge")}},
+		},
+		"swagno.graphEdge": {
+			"from": {Ref: ref("graphNode")},
+			"to":   {Ref: ref("graphNode")},
+			"next": {Type: "object", AdditionalProperties: &definition.DefinitionProperties{Ref: ref("graphEdge")}},
+		},
+		"swagno.listNode": {
+			"value": {Type: "integer", Format: "int64"},
+			"next":  {Ref: ref("listNode")},
+		},
+	}
+	got := map[string]map[string]definition.DefinitionProperties{}
+	for name, def := range sw.Definitions {
+		got[name] = propertiesMap(def.Properties)
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.DefinitionProperties{}, "IsRequired")); diff != "" {
+		t.Errorf("definitions mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type orderedTimestamps struct {
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type orderedModel struct {
+	Zeta  string `json:"zeta" validate:"required"`
+	Alpha int    `json:"alpha,omitempty"`
+	orderedTimestamps
+	Mid bool `json:"mid" validate:"required"`
+}
+
+func TestPropertyOrder(t *testing.T) {
+	sw := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	sw.AddEndpoint(endpoint.New(endpoint.POST, "/ordered", endpoint.WithBody(orderedModel{})))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	def := sw.Definitions["swagno.orderedModel"]
+	if diff := cmp.Diff([]string{"zeta", "alpha", "created_at", "updated_at", "mid"}, def.Properties.Names()); diff != "" {
+		t.Errorf("properties order mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"zeta", "created_at", "updated_at", "mid"}, def.Required); diff != "" {
+		t.Errorf("required order mismatch (-want +got):\n%s", diff)
+	}
+
+	data, err := json.Marshal(def.Properties)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"zeta":{"type":"string"},"alpha":{"type":"integer","format":"int64"},"created_at":{"type":"string"},"updated_at":{"type":"string"},"mid":{"type":"boolean"}}`
+	if string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+
+	var decoded definition.Properties
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(def.Properties, decoded, cmpopts.IgnoreFields(definition.DefinitionProperties{}, "IsRequired")); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type numericModel struct {
+	Small   int8               `json:"small"`
+	Medium  int32              `json:"medium"`
+	Large   int                `json:"large"`
+	Huge    int64              `json:"huge"`
+	Count   uint               `json:"count"`
+	Octet   uint8              `json:"octet"`
+	Ratio   float32            `json:"ratio"`
+	Amount  float64            `json:"amount"`
+	Ports   []uint16           `json:"ports"`
+	Weights map[string]float32 `json:"weights"`
+	Limit   *uint32            `json:"limit"`
+}
+
+func TestNumericFormats(t *testing.T) {
+	sw := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	sw.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/numbers",
+		endpoint.WithParams(parameter.EnumParam("priority", parameter.Query, orderPriority(0))),
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(map[string][]uint64{}, "200", "OK")}),
+		endpoint.WithErrors([]response.Response{response.New(numericModel{}, "400", "Bad Request")}),
+	))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	zero := new(float64)
+	want := map[string]definition.DefinitionProperties{
+		"small":   {Type: "integer", Format: "int32"},
+		"medium":  {Type: "integer", Format: "int32"},
+		"large":   {Type: "integer", Format: "int64"},
+		"huge":    {Type: "integer", Format: "int64"},
+		"count":   {Type: "integer", Format: "int64", Minimum: zero},
+		"octet":   {Type: "integer", Format: "int32", Minimum: zero},
+		"ratio":   {Type: "number", Format: "float"},
+		"amount":  {Type: "number", Format: "double"},
+		"ports":   {Type: "array", Items: &definition.DefinitionPropertiesItems{Type: "integer", Format: "int32", Minimum: zero}},
+		"weights": {Type: "object", AdditionalProperties: &definition.DefinitionProperties{Type: "number", Format: "float"}},
+		"limit":   {Type: "integer", Format: "int64", Minimum: zero},
+	}
+	got := propertiesMap(sw.Definitions["swagno.numericModel"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.DefinitionProperties{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+
+	operation := sw.Paths["/numbers"]["get"]
+	wantResponse := &parameter.JsonResponseSchema{
+		Type: "object",
+		AdditionalProperties: &parameter.JsonResponseSchema{
+			Type:  "array",
+			Items: &parameter.JsonResponseSchemeItems{Type: "integer", Format: "int64", Min: zero},
+		},
+	}
+	if diff := cmp.Diff(wantResponse, operation.Responses["200"].Schema); diff != "" {
+		t.Errorf("response schema mismatch (-want +got):\n%s", diff)
+	}
+	if format := operation.Parameters[0].Format; format != "int64" {
+		t.Errorf("expected enum parameter format int64, got %q", format)
+	}
+}
+
+type jsonRulesDeep struct {
+	Level string `json:"level"`
+}
+
+type jsonRulesLeft struct {
+	jsonRulesDeep
+	Shared string
+	Label  string `json:"Kind"`
+}
+
+type jsonRulesRight struct {
+	Shared string
+	Kind   string
+	Level  int `json:"level"`
+}
+
+type jsonRulesModel struct {
+	Plain  string
+	secret string
+	ID     int64  `json:"id,string"`
+	Flag   bool   `json:",string"`
+	Note   string `json:",omitempty"`
+	Skip   string `json:"-"`
+	Dash   string `json:"-,"`
+	jsonRulesLeft
+	jsonRulesRight
+}
+
+func TestJSONFieldRules(t *testing.T) {
+	sw := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	sw.AddEndpoint(endpoint.New(endpoint.POST, "/json", endpoint.WithBody(jsonRulesModel{})))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	def := sw.Definitions["swagno.jsonRulesModel"]
+	want := definition.Properties{
+		{Name: "Plain", Schema: definition.DefinitionProperties{Type: "string"}},
+		{Name: "id", Schema: definition.DefinitionProperties{Type: "string"}},
+		{Name: "Flag", Schema: definition.DefinitionProperties{Type: "string"}},
+		{Name: "Note", Schema: definition.DefinitionProperties{Type: "string"}},
+		{Name: "-", Schema: definition.DefinitionProperties{Type: "string"}},
+		{Name: "Kind", Schema: definition.DefinitionProperties{Type: "string"}},
+		{Name: "level", Schema: definition.DefinitionProperties{Type: "integer", Format: "int64"}},
+	}
+	if diff := cmp.Diff(want, def.Properties, cmpopts.IgnoreFields(definition.DefinitionProperties{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"Plain", "id", "Flag", "-", "Kind", "level"}, def.Required); diff != "" {
+		t.Errorf("required mismatch (-want +got):\n%s", diff)
+	}
+
+	// the documented properties are the keys encoding/json writes
+	data, err := json.Marshal(jsonRulesModel{Note: "set"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var encoded map[string]interface{}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for key := range encoded {
+		keys = append(keys, key)
+	}
+	if diff := cmp.Diff(def.Properties.Names(), keys, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("encoded keys mismatch (-documented +encoded):\n%s", diff)
+	}
+}
+
+type anonymousModel struct {
+	Meta struct {
+		Version int `json:"version"`
+	} `json:"meta"`
+	Owner *struct {
+		Name string `json:"name"`
+	} `json:"owner"`
+	Lines []struct {
+		SKU string `json:"sku"`
+		Qty int    `json:"qty,omitempty"`
+	} `json:"lines"`
+	Notes map[string]struct {
+		Text string `json:"text"`
+	} `json:"notes"`
+}
+
+func TestAnonymousStructs(t *testing.T) {
+	sw := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	sw.AddEndpoint(endpoint.New(endpoint.POST, "/anonymous", endpoint.WithBody(anonymousModel{})))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	object := func(required []string, properties ...definition.Property) definition.DefinitionProperties {
+		return definition.DefinitionProperties{Type: "object", Properties: properties, Required: required}
+	}
+	owner := object([]string{"name"}, definition.Property{Name: "name", Schema: definition.DefinitionProperties{Type: "string"}})
+	line := object([]string{"sku"},
+		definition.Property{Name: "sku", Schema: definition.DefinitionProperties{Type: "string"}},
+		definition.Property{Name: "qty", Schema: definition.DefinitionProperties{Type: "integer", Format: "int64"}},
+	)
+	note := object([]string{"text"}, definition.Property{Name: "text", Schema: definition.DefinitionProperties{Type: "string"}})
+	want := map[string]definition.DefinitionProperties{
+		"meta":  object([]string{"version"}, definition.Property{Name: "version", Schema: definition.DefinitionProperties{Type: "integer", Format: "int64"}}),
+		"owner": owner,
+		"lines": {Type: "array", Items: &definition.DefinitionPropertiesItems{Type: line.Type, Properties: line.Properties, Required: line.Required}},
+		"notes": {Type: "object", AdditionalProperties: &note},
+	}
+	got := propertiesMap(sw.Definitions["swagno.anonymousModel"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.DefinitionProperties{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+
+	var names []string
+	for name := range sw.Definitions {
+		names = append(names, name)
+	}
+	if diff := cmp.Diff([]string{"swagno.anonymousModel"}, names); diff != "" {
+		t.Errorf("definitions mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAnonymousStructResponses(t *testing.T) {
+	sw := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	sw.AddEndpoint(endpoint.New(
+		endpoint.POST,
+		"/anonymous",
+		endpoint.WithBody(struct {
+			Name  string       `json:"name" example:"Ada" desc:"Display name" validate:"max=20"`
+			Point *nestedPoint `json:"point"`
+			Label *string      `json:"label"`
+		}{}),
+		endpoint.WithSuccessfulReturns([]response.Response{response.New([]struct {
+			ID    int          `json:"id"`
+			Point *nestedPoint `json:"point,omitempty"`
+		}{}, "200", "OK")}),
+		endpoint.WithErrors([]response.Response{response.New(struct {
+			Message string `json:"message"`
+		}{}, "400", "Bad Request")}),
+	))
+	data, err := sw.ToJson()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operation := sw.Paths["/anonymous"]["post"]
+	for _, tc := range []struct {
+		name   string
+		schema *parameter.JsonResponseSchema
+		want   string
+	}{
+		{"body", operation.Parameters[0].Schema, `{"type":"object","properties":{"name":{"type":"string","example":"Ada","description":"Display name","maxLength":20},"point":{"$ref":"#/definitions/swagno.nestedPoint"},"label":{"type":"string"}},"required":["name"]}`},
+		{"response", operation.Responses["200"].Schema, `{"type":"array","items":{"type":"object","properties":{"id":{"type":"integer","format":"int64"},"point":{"$ref":"#/definitions/swagno.nestedPoint"}},"required":["id"]}}`},
+		{"error", operation.Responses["400"].Schema, `{"type":"object","properties":{"message":{"type":"string"}},"required":["message"]}`},
+	} {
+		got, err := json.Marshal(tc.schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("%s schema = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if dangling := danglingRefs(doc, doc); len(dangling) > 0 {
+		t.Errorf("references not resolving in the document: %v", dangling)
+	}
+}
+
+func TestBodyJsonParameter(t *testing.T) {
+	e := endpoint.New(endpoint.POST, "/points", endpoint.WithBody(nestedPoint{}))
+	if got := e.BodyJsonParameter(true).Schema.Ref; got != "#/definitions/nestedPoint" {
+		t.Errorf("body schema ref = %q, want %q", got, "#/definitions/nestedPoint")
+	}
+}
+
+// danglingRefs returns the '$ref' values found in value which do not resolve to a
+// value of the decoded document doc.
+func danglingRefs(doc, value interface{}) []string {
+	var dangling []string
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok {
+			var target interface{} = doc
+			for _, segment := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+				object, _ := target.(map[string]interface{})
+				target = object[strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)]
+			}
+			if target == nil {
+				dangling = append(dangling, ref)
+			}
+		}
+		for _, child := range value {
+			dangling = append(dangling, danglingRefs(doc, child)...)
+		}
+	case []interface{}:
+		for _, child := range value {
+			dangling = append(dangling, danglingRefs(doc, child)...)
+		}
+	}
+	return dangling
+}
+
+type nestedPoint struct {
+	X int `json:"x"`
+}
+
+type nestedArraysModel struct {
+	Matrix  [][]int              `json:"matrix"`
+	Paths   [][]nestedPoint      `json:"paths"`
+	Groups  []*[]string          `json:"groups"`
+	Lookups []map[string][]int32 `json:"lookups"`
+	RGB     [3]uint8             `json:"rgb"`
+	Grid    *[2][2]float64       `json:"grid"`
+}
+
+func TestNestedArrays(t *testing.T) {
+	sw := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	sw.AddEndpoint(endpoint.New(
+		endpoint.POST,
+		"/nested",
+		endpoint.WithBody([][3]int{}),
+		endpoint.WithSuccessfulReturns([]response.Response{response.New([][]nestedPoint{}, "200", "OK")}),
+		endpoint.WithErrors([]response.Response{response.New(nestedArraysModel{}, "400", "Bad Request")}),
+	))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	two, three := int64(2), int64(3)
+	point := "#/definitions/swagno.nestedPoint"
+	want := map[string]definition.DefinitionProperties{
+		"matrix": {Type: "array", Items: &definition.DefinitionPropertiesItems{Type: "array", Items: &definition.DefinitionPropertiesItems{Type: "integer", Format: "int64"}}},
+		"paths":  {Type: "array", Items: &definition.DefinitionPropertiesItems{Type: "array", Items: &definition.DefinitionPropertiesItems{Ref: point}}},
+		"groups": {Type: "array", Items: &definition.DefinitionPropertiesItems{Type: "array", Items: &definition.DefinitionPropertiesItems{Type: "string"}}},
+		"lookups": {Type: "array", Items: &definition.DefinitionPropertiesItems{
+			Type:                 "object",
+			AdditionalProperties: &definition.DefinitionProperties{Type: "array", Items: &definition.DefinitionPropertiesItems{Type: "integer", Format: "int32"}},
+		}},
+		"rgb": {Type: "array", Items: &definition.DefinitionPropertiesItems{Type: "integer", Format: "int32", Minimum: new(float64)}, MinItems: &three, MaxItems: &three},
+		"grid": {
+			Type:     "array",
+			Items:    &definition.DefinitionPropertiesItems{Type: "array", Items: &definition.DefinitionPropertiesItems{Type: "number", Format: "double"}, MinItems: &two, MaxItems: &two},
+			MinItems: &two,
+			MaxItems: &two,
+		},
+	}
+	got := propertiesMap(sw.Definitions["swagno.nestedArraysModel"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.DefinitionProperties{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+
+	operation := sw.Paths["/nested"]["post"]
+	wantBody := &parameter.JsonResponseSchema{
+		Type:  "array",
+		Items: &parameter.JsonResponseSchemeItems{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "integer", Format: "int64"}, MinItems: &three, MaxItems: &three},
+	}
+	if diff := cmp.Diff(wantBody, operation.Parameters[0].Schema); diff != "" {
+		t.Errorf("body schema mismatch (-want +got):\n%s", diff)
+	}
+	wantResponse := &parameter.JsonResponseSchema{
+		Type:  "array",
+		Items: &parameter.JsonResponseSchemeItems{Type: "array", Items: &parameter.JsonResponseSchemeItems{Ref: point}},
+	}
+	if diff := cmp.Diff(wantResponse, operation.Responses["200"].Schema); diff != "" {
+		t.Errorf("response schema mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPrimitiveModels(t *testing.T) {
+	zero := new(float64)
+	tests := []struct {
+		name  string
+		model any
+		want  *parameter.JsonResponseSchema
+	}{
+		{"string", "", &parameter.JsonResponseSchema{Type: "string"}},
+		{"int", 0, &parameter.JsonResponseSchema{Type: "integer", Format: "int64"}},
+		{"uint8", uint8(0), &parameter.JsonResponseSchema{Type: "integer", Format: "int32", Min: zero}},
+		{"bool pointer", new(bool), &parameter.JsonResponseSchema{Type: "boolean"}},
+		{"bytes", []byte{}, &parameter.JsonResponseSchema{Type: "string", Format: "byte"}},
+		{"time", time.Time{}, &parameter.JsonResponseSchema{Type: "string", Format: "date-time"}},
+		{"slice", []string{}, &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "string"}}},
+		{"map", map[string]int{}, &parameter.JsonResponseSchema{Type: "object", AdditionalProperties: &parameter.JsonResponseSchema{Type: "integer", Format: "int64"}}},
+		{"struct pointer", &nestedPoint{}, &parameter.JsonResponseSchema{Ref: "#/definitions/swagno.nestedPoint"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sw := New(Config{Title: "Testing API", Version: "v1.0.0"})
+			path := "/" + strings.ReplaceAll(tt.name, " ", "-")
+			sw.AddEndpoint(endpoint.New(
+				endpoint.POST,
+				path,
+				endpoint.WithBody(tt.model),
+				endpoint.WithSuccessfulReturns([]response.Response{response.New(tt.model, "200", "OK")}),
+			))
+			if err := sw.generateSwaggerJson(); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, sw.Paths[path]["post"].Responses["200"].Schema); diff != "" {
+				t.Errorf("response schema mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.want, sw.Paths[path]["post"].Parameters[0].Schema); diff != "" {
+				t.Errorf("body schema mismatch (-want +got):\n%s", diff)
+			}
+			names := []string{}
+			for name := range sw.Definitions {
+				names = append(names, name)
+			}
+			if tt.want.Ref == "" && len(names) > 0 {
+				t.Errorf("unexpected definitions %v", names)
+			}
+		})
+	}
+}
+
+type documentedModel struct {
+	ID       string       `json:"id" format:"uuid" readonly:"true"`
+	Limit    int          `json:"limit" default:"10"`
+	Ratio    *float32     `json:"ratio" default:"0.5" nullable:"true"`
+	Enabled  bool         `json:"enabled" default:"true"`
+	Tags     []string     `json:"tags" default:"[\"a\",\"b\"]"`
+	Password string       `json:"password" writeonly:"true" pattern:"^[a-z]+$"`
+	Legacy   string       `json:"legacy" deprecated:"true" default:"x"`
+	Count    int64        `json:"count,string" default:"3"`
+	Since    time.Time    `json:"since" default:"2024-01-01T00:00:00Z"`
+	Owner    *nestedPoint `json:"owner" readonly:"true"`
+}
+
+func TestDocumentationTags(t *testing.T) {
+	sw := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	sw.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/documented",
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(documentedModel{}, "200", "OK")}),
+	))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]definition.DefinitionProperties{
+		"id":       {Type: "string", Format: "uuid", ReadOnly: true},
+		"limit":    {Type: "integer", Format: "int64", Default: int64(10)},
+		"ratio":    {Type: "number", Format: "float", Default: 0.5, XNullable: true},
+		"enabled":  {Type: "boolean", Default: true},
+		"tags":     {Type: "array", Items: &definition.DefinitionPropertiesItems{Type: "string"}, Default: []string{"a", "b"}},
+		"password": {Type: "string", Pattern: "^[a-z]+$", XWriteOnly: true},
+		"legacy":   {Type: "string", Default: "x", XDeprecated: true},
+		"count":    {Type: "string", Default: "3"},
+		"since":    {Type: "string", Format: "date-time", Default: "2024-01-01T00:00:00Z"},
+		"owner":    {Ref: "#/definitions/swagno.nestedPoint"},
+	}
+	got := propertiesMap(sw.Definitions["swagno.documentedModel"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.DefinitionProperties{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+
+	data, err := json.Marshal(got["legacy"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"x-deprecated":true`) {
+		t.Errorf("legacy property %s does not mark deprecation", data)
+	}
+}
+
+type typedExamplesModel struct {
+	Code    string            `json:"code" example:"0123"`
+	Offset  int               `json:"offset" example:"-5"`
+	Ratio   float64           `json:"ratio" example:"0.25"`
+	Active  bool              `json:"active" example:"true"`
+	IDs     []int             `json:"ids" example:"[1,2,3]"`
+	Labels  map[string]string `json:"labels" example:"{\"env\":\"prod\"}"`
+	Since   time.Time         `json:"since" example:"2024-01-01T00:00:00Z"`
+	Count   *uint8            `json:"count" example:"7"`
+	Version int64             `json:"version,string" example:"3"`
+}
+
+type invalidExamplesModel struct {
+	Age int   `json:"age" example:"old"`
+	IDs []int `json:"ids" example:"[1,"`
+}
+
+func TestTypedExamples(t *testing.T) {
+	sw := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	sw.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/examples",
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(typedExamplesModel{}, "200", "OK")}),
+	))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"code":    "0123",
+		"offset":  int64(-5),
+		"ratio":   0.25,
+		"active":  true,
+		"ids":     []int{1, 2, 3},
+		"labels":  map[string]string{"env": "prod"},
+		"since":   "2024-01-01T00:00:00Z",
+		"count":   uint64(7),
+		"version": "3",
+	}
+	got := map[string]interface{}{}
+	for name, property := range propertiesMap(sw.Definitions["swagno.typedExamplesModel"].Properties) {
+		got[name] = property.Example
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("examples mismatch (-want +got):\n%s", diff)
+	}
+
+	newInvalid := func(strict bool) *Swagger {
+		invalid := New(Config{Title: "Testing API", Version: "v1.0.0", StrictExamples: strict})
+		invalid.AddEndpoint(endpoint.New(
+			endpoint.GET,
+			"/invalid",
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(invalidExamplesModel{}, "200", "OK")}),
+		))
+		return invalid
+	}
+	checkErrors := func(t *testing.T, errs []error) {
+		if len(errs) != 2 {
+			t.Fatalf("expected 2 example errors, got %v", errs)
+		}
+		for i, want := range []string{`example "[1," of field IDs is not a valid []int`, `example "old" of field Age is not a valid int`} {
+			if msg := errs[i].Error(); !strings.Contains(msg, want) {
+				t.Errorf("error %d: %q does not contain %q", i, msg, want)
+			}
+		}
+	}
+
+	t.Run("invalid examples are reported", func(t *testing.T) {
+		invalid := newInvalid(false)
+		if _, err := invalid.ToJson(); err != nil {
+			t.Fatalf("expected invalid examples not to fail, got %v", err)
+		}
+		checkErrors(t, invalid.ExampleErrors())
+		got := propertiesMap(invalid.Definitions["swagno.invalidExamplesModel"].Properties)
+		if got["age"].Example != "old" || got["ids"].Example != "[1," {
+			t.Errorf("expected the examples as written, got %v and %v", got["age"].Example, got["ids"].Example)
+		}
+	})
+
+	t.Run("strict examples", func(t *testing.T) {
+		_, err := newInvalid(true).ToJson()
+		var exampleErr *ExampleError
+		if !errors.As(err, &exampleErr) {
+			t.Fatalf("expected *ExampleError, got %v", err)
+		}
+		checkErrors(t, exampleErr.Errors)
+	})
+}
+
+type commentedBase struct {
+	ID int `json:"id"`
+}
+
+type commentedModel struct {
+	commentedBase
+	Email string `json:"email"`
+	Nick  string `json:"nick" desc:"Explicit description"`
+	Plain string `json:"plain"`
+}
+
+func TestDocComments(t *testing.T) {
+	pkgPath := reflect.TypeOf(commentedModel{}).PkgPath()
+	comments := fields.Comments{
+		pkgPath + ".commentedModel":       "A model described by its doc comment.",
+		pkgPath + ".commentedModel.Email": "Email doc.",
+		pkgPath + ".commentedModel.Nick":  "Nick doc.",
+		pkgPath + ".commentedBase.ID":     "ID doc.",
+	}
+	data, err := comments.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed, err := fields.ParseComments(data); err != nil || !cmp.Equal(comments, parsed) {
+		t.Errorf("ParseComments() = %v, %v; want %v", parsed, err, comments)
+	}
+
+	sw := New(Config{Title: "Testing API", Version: "v1.0.0", Comments: comments})
+	sw.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/commented",
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(commentedModel{}, "200", "OK")}),
+	))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	model := sw.Definitions["swagno.commentedModel"]
+	if model.Description != "A model described by its doc comment." {
+		t.Errorf("description = %q", model.Description)
+	}
+	want := map[string]string{
+		"id":    "ID doc.",
+		"email": "Email doc.",
+		"nick":  "Explicit description",
+		"plain": "",
+	}
+	got := map[string]string{}
+	for name, property := range propertiesMap(model.Properties) {
+		got[name] = property.Description
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("property descriptions mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type paramsPage struct {
+	Page  int `form:"page" default:"1" validate:"min=1"`
+	Limit int `form:"limit" binding:"max=100"`
+}
+
+type paramsModel struct {
+	paramsPage
+	ID       int64         `uri:"id"`
+	Token    string        `header:"X-Token" binding:"required"`
+	Query    *string       `query:"q" validate:"min=3,max=50" desc:"Search terms"`
+	Status   orderStatus   `form:"status"`
+	Statuses []orderStatus `form:"statuses" validate:"max=2,unique"`
+	Since    time.Time     `form:"since"`
+	Sort     string        `form:"sort" validate:"oneof=asc desc"`
+	Ratio    float32       `form:"ratio"`
+	Price    float64       `form:"price" validate:"gt=0,lte=9.99"`
+	Count    uint          `form:"count"`
+	Body     string        `json:"body"`
+	Ignored  string        `form:"-"`
+}
+
+func TestParamsFrom(t *testing.T) {
+	sw := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	sw.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/orders/{id}",
+		endpoint.WithParamsFrom(paramsModel{}),
+	))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	zero, one, hundred, price := float64(0), float64(1), float64(100), 9.99
+	statusValues := []interface{}{orderStatusNew, orderStatusPaid}
+	want := []parameter.JsonParameter{
+		{Name: "page", In: "query", Type: "integer", Format: "int64", Default: int64(1), Min: &one, Description: " (min: 1)"},
+		{Name: "limit", In: "query", Type: "integer", Format: "int64", Max: &hundred, Description: " (max: 100)"},
+		{Name: "id", In: "path", Type: "integer", Format: "int64", Required: true},
+		{Name: "X-Token", In: "header", Type: "string", Required: true},
+		{Name: "q", In: "query", Type: "string", MinLen: 3, MaxLen: 50, Description: "Search terms\n (minLength: 3 maxLength: 50)"},
+		{Name: "status", In: "query", Type: "string", Enum: statusValues},
+		{Name: "statuses", In: "query", Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "string", Enum: statusValues}, MaxItems: 2, UniqueItems: true, CollenctionFormat: "multi"},
+		{Name: "since", In: "query", Type: "string", Format: "date-time"},
+		{Name: "sort", In: "query", Type: "string", Enum: []interface{}{"asc", "desc"}},
+		{Name: "ratio", In: "query", Type: "number", Format: "float"},
+		{Name: "price", In: "query", Type: "number", Format: "double", Min: &zero, ExclusiveMin: true, Max: &price, Description: " (exclusive min: 0 max: 9.99)"},
+		{Name: "count", In: "query", Type: "integer", Format: "int64", Min: &zero, Description: " (min: 0)"},
+	}
+	if diff := cmp.Diff(want, sw.Paths["/orders/{id}"]["get"].Parameters); diff != "" {
+		t.Errorf("parameters mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type typedParamsModel struct {
+	ID      uuidLike          `uri:"id"`
+	Related []uuidLike        `form:"related"`
+	Filter  paramsPage        `form:"filter"`
+	Labels  map[string]string `form:"labels"`
+	Legacy  string            `form:"legacy" deprecated:"true" example:"old"`
+}
+
+func TestParamsFromTypes(t *testing.T) {
+	cfg := Config{Title: "Testing API", Version: "v1.0.0"}
+	cfg.RegisterType(reflect.TypeOf(uuidLike{}), fields.TypeSchema{Type: "string", Format: "uuid"})
+	sw := New(cfg)
+	sw.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/orders/{id}",
+		endpoint.WithParamsFrom(typedParamsModel{}),
+	))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []parameter.JsonParameter{
+		{Name: "id", In: "path", Type: "string", Format: "uuid", Required: true},
+		{Name: "related", In: "query", Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "string", Format: "uuid"}, CollenctionFormat: "multi"},
+		{Name: "legacy", In: "query", Type: "string", XDeprecated: true, XExample: "old"},
+	}
+	if diff := cmp.Diff(want, sw.Paths["/orders/{id}"]["get"].Parameters); diff != "" {
+		t.Errorf("parameters mismatch (-want +got):\n%s", diff)
+	}
+
+	// without the registration, the uuid keeps the array of bytes it is in Go
+	params := parameter.ParamsFrom(typedParamsModel{})
+	if len(params) != 3 {
+		t.Fatalf("got %d parameters, want the struct and map fields skipped", len(params))
+	}
+	if got := params[0].AsJson(); got.Type != "array" || got.Items.Type != "integer" {
+		t.Errorf("unregistered id = %+v, want an array of integers", got)
+	}
+}
+
+type arrayParamsModel struct {
+	IDs    []uint          `form:"ids" collection_format:"csv"`
+	Matrix [][]int32       `form:"matrix"`
+	Tags   []string        `header:"X-Tags"`
+	Days   [7]float64      `form:"days"`
+	Levels []orderPriority `form:"levels"`
+}
+
+func TestArrayParams(t *testing.T) {
+	cfg := Config{Title: "Testing API", Version: "v1.0.0"}
+	cfg.RegisterEnum(reflect.TypeOf(orderPriority(0)), fields.Enum{Values: []interface{}{1, 2, 3}})
+	sw := New(cfg)
+	sw.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/arrays",
+		endpoint.WithParams(
+			parameter.IntArrParam("ids", parameter.Query, []int64{1, 2}, parameter.WithCollectionFormat(parameter.Multi)),
+			parameter.StrArrParam("names", parameter.Query, nil),
+			parameter.ArrParam("grid", parameter.Query, &parameter.JsonResponseSchemeItems{
+				Type:             "array",
+				CollectionFormat: "csv",
+				Items:            &parameter.JsonResponseSchemeItems{Type: "integer", Format: "int32"},
+			}, parameter.WithCollectionFormat(parameter.Pipes)),
+		),
+		endpoint.WithParamsFrom(arrayParamsModel{}),
+	))
+	if err := sw.generateSwaggerJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	zero := float64(0)
+	want := []parameter.JsonParameter{
+		{Name: "ids", In: "query", Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "integer", Enum: []interface{}{int64(1), int64(2)}}, CollenctionFormat: "multi"},
+		{Name: "names", In: "query", Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "string"}},
+		{Name: "grid", In: "query", Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "array", CollectionFormat: "csv", Items: &parameter.JsonResponseSchemeItems{Type: "integer", Format: "int32"}}, CollenctionFormat: "pipes"},
+		{Name: "ids", In: "query", Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "integer", Format: "int64", Min: &zero}, CollenctionFormat: "csv"},
+		{Name: "matrix", In: "query", Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "integer", Format: "int32"}}, CollenctionFormat: "multi"},
+		{Name: "X-Tags", In: "header", Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "string"}},
+		{Name: "days", In: "query", Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "number", Format: "double"}, CollenctionFormat: "multi"},
+		{Name: "levels", In: "query", Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "integer", Format: "int64", Enum: []interface{}{1, 2, 3}}, CollenctionFormat: "multi"},
+	}
+	if diff := cmp.Diff(want, sw.Paths["/arrays"]["get"].Parameters); diff != "" {
+		t.Errorf("parameters mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPathParams(t *testing.T) {
+	newSwagger := func(auto bool, endpoints ...*endpoint.EndPoint) (*Swagger, error) {
+		sw := New(Config{Title: "Testing API", Version: "v1.0.0", AutoPathParams: auto})
+		sw.AddEndpoints(endpoints)
+		return sw, sw.generateSwaggerJson()
+	}
+
+	t.Run("auto", func(t *testing.T) {
+		sw, err := newSwagger(true,
+			endpoint.New(endpoint.GET, "/orders/{orderId}/items/{itemId}",
+				endpoint.WithParams(parameter.IntParam("orderId", parameter.Path, parameter.WithRequired())),
+			),
+			endpoint.New(endpoint.GET, "/files/:bucket/*path"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []parameter.JsonParameter{
+			{Name: "orderId", In: "path", Type: "integer", Required: true},
+			{Name: "itemId", In: "path", Type: "string", Required: true},
+		}
+		if diff := cmp.Diff(want, sw.Paths["/orders/{orderId}/items/{itemId}"]["get"].Parameters); diff != "" {
+			t.Errorf("parameters mismatch (-want +got):\n%s", diff)
+		}
+
+		operation, ok := sw.Paths["/files/{bucket}/{path}"]["get"]
+		if !ok {
+			t.Fatalf("gin path not converted, paths: %v", sw.Paths)
+		}
+		want = []parameter.JsonParameter{
+			{Name: "bucket", In: "path", Type: "string", Required: true},
+			{Name: "path", In: "path", Type: "string", Required: true},
+		}
+		if diff := cmp.Diff(want, operation.Parameters); diff != "" {
+			t.Errorf("parameters mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("opt-in", func(t *testing.T) {
+		sw, err := newSwagger(false, endpoint.New(endpoint.GET, "/orders/{orderId}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if params := sw.Paths["/orders/{orderId}"]["get"].Parameters; len(params) != 0 {
+			t.Errorf("parameters = %v, want none without AutoPathParams", params)
+		}
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		_, err := newSwagger(true,
+			endpoint.New(endpoint.GET, "/orders/:orderId",
+				endpoint.WithParams(
+					parameter.IntParam("orderId", parameter.Path),
+					parameter.IntParam("itemId", parameter.Path, parameter.WithRequired()),
+				),
+			),
+		)
+		var pathErr *PathParamError
+		if !errors.As(err, &pathErr) {
+			t.Fatalf("err = %v, want a *PathParamError", err)
+		}
+		want := []string{
+			`GET /orders/{orderId}: path parameter "orderId" is not required`,
+			`GET /orders/{orderId}: path parameter "itemId" is not in the path`,
+		}
+		var got []string
+		for _, err := range pathErr.Errors {
+			got = append(got, err.Error())
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("errors mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		_, err := newSwagger(true,
+			endpoint.New(endpoint.GET, "/orders/:orderId"),
+			endpoint.New(endpoint.DELETE, "/orders/:orderId"),
+			endpoint.New(endpoint.GET, "/orders/{orderId}"),
+		)
+		var duplicateErr *DuplicateOperationError
+		if !errors.As(err, &duplicateErr) {
+			t.Fatalf("err = %v, want a *DuplicateOperationError", err)
+		}
+		want := []string{`GET /orders/{orderId}: already documented by GET /orders/:orderId`}
+		var got []string
+		for _, err := range duplicateErr.Errors {
+			got = append(got, err.Error())
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("errors mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+type componentsError struct {
+	Message string `json:"message"`
+}
+
+func TestComponentRefs(t *testing.T) {
+	newSwagger := func(endpoints ...*endpoint.EndPoint) *Swagger {
+		sw := New(Config{Title: "Testing API", Version: "v1.0.0", AutoPathParams: true})
+		sw.RegisterParameter("orderId", parameter.IntParam("orderId", parameter.Path, parameter.WithRequired()))
+		sw.RegisterParameter("page", parameter.IntParam("page", parameter.Query, parameter.WithDefault(1)))
+		sw.RegisterResponse("Unauthorized", response.New(componentsError{}, "401", "Unauthorized"))
+		sw.AddEndpoints(endpoints)
+		return sw
+	}
+
+	t.Run("refs", func(t *testing.T) {
+		sw := newSwagger(endpoint.New(endpoint.GET, "/orders/{orderId}",
+			endpoint.WithParamRefs("orderId", "page"),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(componentsError{}, "200", "OK")}),
+			endpoint.WithResponseRefs("Unauthorized"),
+		))
+		if err := sw.generateSwaggerJson(); err != nil {
+			t.Fatal(err)
+		}
+
+		operation := sw.Paths["/orders/{orderId}"]["get"]
+		got, err := json.Marshal(map[string]interface{}{
+			"parameters": operation.Parameters,
+			"401":        operation.Responses["401"],
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `{"401":{"$ref":"#/responses/Unauthorized"},` +
+			`"parameters":[{"$ref":"#/parameters/orderId"},{"$ref":"#/parameters/page"}]}`
+		if string(got) != want {
+			t.Errorf("operation refs = %s, want %s", got, want)
+		}
+		if _, ok := operation.Responses["200"]; !ok {
+			t.Errorf("responses = %v, want the successful return kept", operation.Responses)
+		}
+
+		wantParam := parameter.JsonParameter{Name: "page", In: "query", Type: "integer", Default: 1}
+		if diff := cmp.Diff(wantParam, sw.Parameters["page"]); diff != "" {
+			t.Errorf("page parameter mismatch (-want +got):\n%s", diff)
+		}
+		unauthorized := sw.Responses["Unauthorized"]
+		if unauthorized.Description != "Unauthorized" || unauthorized.Schema.Ref != "#/definitions/swagno.componentsError" {
+			t.Errorf("Unauthorized response = %+v, want the componentsError definition", unauthorized)
+		}
+		if _, ok := sw.Definitions["swagno.componentsError"]; !ok {
+			t.Errorf("definition swagno.componentsError missing")
+		}
+	})
+
+	t.Run("unregistered", func(t *testing.T) {
+		sw := newSwagger(endpoint.New(endpoint.GET, "/orders",
+			endpoint.WithParamRefs("limit"),
+			endpoint.WithResponseRefs("NotFound"),
+		))
+		err := sw.generateSwaggerJson()
+		var refErr *ComponentRefError
+		if !errors.As(err, &refErr) {
+			t.Fatalf("err = %v, want a *ComponentRefError", err)
+		}
+		want := []string{
+			`GET /orders: parameter "limit" is not registered`,
+			`GET /orders: response "NotFound" is not registered`,
+		}
+		var got []string
+		for _, err := range refErr.Errors {
+			got = append(got, err.Error())
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("errors mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+// propertiesMap returns the properties keyed by name, for comparisons ignoring their order.
+func propertiesMap(properties definition.Properties) map[string]definition.DefinitionProperties {
+	m := map[string]definition.DefinitionProperties{}
+	for _, property := range properties {
+		m[property.Name] = property.Schema
+	}
+	return m
+}
@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"log"
 	"os"
+	"reflect"
 
 	"github.com/go-swagno/swagno/components/definition"
 	"github.com/go-swagno/swagno/components/endpoint"
+	"github.com/go-swagno/swagno/components/fields"
+	"github.com/go-swagno/swagno/components/http/response"
+	"github.com/go-swagno/swagno/components/parameter"
 	"github.com/go-swagno/swagno/components/tag"
 )
 
@@ -19,11 +23,24 @@ type Swagger struct {
 	BasePath            string                                      `json:"basePath" default:"/"`
 	Host                string                                      `json:"host" default:""`
 	Definitions         map[string]definition.Definition            `json:"definitions"`
+	Parameters          map[string]parameter.JsonParameter          `json:"parameters,omitempty"`
+	Responses           map[string]endpoint.JsonResponse            `json:"responses,omitempty"`
 	Schemes             []string                                    `json:"schemes,omitempty"`
 	Tags                []tag.Tag                                   `json:"tags,omitempty"`
 	SecurityDefinitions map[string]securityDefinition               `json:"securityDefinitions,omitempty"`
 	endpoints           []*endpoint.EndPoint
 	hidePackageName     bool
+	types               fields.Types
+	enums               fields.Enums
+	enumSchemas         bool
+	interfaces          fields.Interfaces
+	nameStrategy        fields.NameStrategy
+	comments            fields.Comments
+	autoPathParams      bool
+	strictExamples      bool
+	exampleErrors       []error
+	parameters          map[string]*parameter.Parameter
+	responses           map[string]response.Response
 }
 
 // Info represents the information about the API.
@@ -97,6 +114,68 @@ type Config struct {
 	// HidePackageName, when true, references models without their package qualifier
 	// in the generated documentation (e.g. "MyStruct" instead of "models.MyStruct").
 	HidePackageName bool
+	// Types maps Go types to the schema they are documented with, overriding reflection.
+	// Use RegisterType to add entries. Built-in mappings exist for time.Time, []byte,
+	// json.RawMessage, net.IP, url.URL and the sql.Null* types.
+	Types fields.Types
+	// Enums maps named types to their allowed values. Use RegisterEnum to add entries.
+	// Types implementing fields.Enumer do not need to be registered.
+	Enums fields.Enums
+	// EnumSchemas, when true, documents enum types as reusable definitions with an
+	// 'x-enum-varnames' extension instead of repeating their values inline.
+	EnumSchemas bool
+	// Interfaces maps interface types to their concrete implementations. Use
+	// RegisterInterface to add entries. Unregistered interfaces are documented as
+	// unconstrained values.
+	Interfaces fields.Interfaces
+	// NameStrategy names the definitions of models and the references to them, e.g.
+	// fields.PathSegmentNames(2) or a fields.NameFunc callback. It defaults to
+	// fields.QualifiedNames, or fields.UnqualifiedNames with HidePackageName.
+	NameStrategy fields.NameStrategy
+	// GenericName names the instantiations of generic types for the built-in name
+	// strategies, e.g. "models.PageOfProduct" instead of the default "models.Page_Product".
+	GenericName fields.GenericNameFunc
+	// Comments holds the doc comments of models and their fields, which describe the
+	// definitions and properties without a 'desc' tag. It is usually generated by the
+	// swagno-comments command and decoded with fields.ParseComments.
+	Comments fields.Comments
+	// AutoPathParams, when true, documents the '{name}' segments of endpoint paths that
+	// no path parameter is declared for as required string parameters.
+	AutoPathParams bool
+	// StrictExamples, when true, makes ToJson return an *ExampleError (and MustToJson
+	// panic) when 'example' struct tags cannot be converted to the type of their field.
+	// By default these examples are documented as written, logged and reported by
+	// ExampleErrors.
+	StrictExamples bool
+}
+
+// RegisterType documents every value of type t with the given schema instead of
+// reflecting it, e.g. RegisterType(reflect.TypeOf(uuid.UUID{}), fields.TypeSchema{Type: "string", Format: "uuid"}).
+func (c *Config) RegisterType(t reflect.Type, schema fields.TypeSchema) {
+	if c.Types == nil {
+		c.Types = fields.Types{}
+	}
+	c.Types[t] = schema
+}
+
+// RegisterEnum documents the allowed values of the named type t on every property,
+// array item and parameter of that type, e.g.
+// RegisterEnum(reflect.TypeOf(OrderStatus("")), fields.Enum{Values: []interface{}{StatusNew, StatusPaid}}).
+func (c *Config) RegisterEnum(t reflect.Type, enum fields.Enum) {
+	if c.Enums == nil {
+		c.Enums = fields.Enums{}
+	}
+	c.Enums[t] = enum
+}
+
+// RegisterInterface documents fields of the interface type t as one of the given
+// implementations, told apart by a discriminating property, e.g.
+// RegisterInterface(reflect.TypeOf((*Shape)(nil)).Elem(), fields.Implementations{PropertyName: "kind", Mapping: map[string]interface{}{"circle": Circle{}}}).
+func (c *Config) RegisterInterface(t reflect.Type, implementations fields.Implementations) {
+	if c.Interfaces == nil {
+		c.Interfaces = fields.Interfaces{}
+	}
+	c.Interfaces[t] = implementations
 }
 
 // buildSwagger creates a new swagger instance with the given title, version, and optional arguments.
@@ -111,6 +190,16 @@ func buildSwagger(c Config) (swagger *Swagger) {
 		c.Path = "/"
 	}
 
+	if c.NameStrategy == nil {
+		c.NameStrategy = fields.QualifiedNames()
+		if c.HidePackageName {
+			c.NameStrategy = fields.UnqualifiedNames()
+		}
+	}
+	if c.GenericName != nil {
+		c.NameStrategy = fields.GenericNames(c.NameStrategy, c.GenericName)
+	}
+
 	swagger = &Swagger{
 		Swagger: "2.0",
 		Info: Info{
@@ -130,6 +219,14 @@ func buildSwagger(c Config) (swagger *Swagger) {
 		SecurityDefinitions: make(map[string]securityDefinition),
 		endpoints:           []*endpoint.EndPoint{},
 		hidePackageName:     c.HidePackageName,
+		types:               c.Types,
+		enums:               c.Enums,
+		enumSchemas:         c.EnumSchemas,
+		interfaces:          c.Interfaces,
+		nameStrategy:        c.NameStrategy,
+		comments:            c.Comments,
+		autoPathParams:      c.AutoPathParams,
+		strictExamples:      c.StrictExamples,
 	}
 
 	return
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/go-swagno/swagno/v3/components/fields"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoadComments(t *testing.T) {
+	loaded, err := loadComments("../../testdata/comments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := fields.Comments{
+		"github.com/go-swagno/swagno/v3/testdata/comments.User":       "User is a registered account.",
+		"github.com/go-swagno/swagno/v3/testdata/comments.User.Email": "Email is where notifications are sent.",
+		"github.com/go-swagno/swagno/v3/testdata/comments.User.Name":  "Name is the display name.",
+		"github.com/go-swagno/swagno/v3/testdata/comments.Status":     "Status is the state of an order.",
+	}
+	if diff := cmp.Diff(want, loaded); diff != "" {
+		t.Errorf("loaded comments mismatch (-want +got):\n%s", diff)
+	}
+}
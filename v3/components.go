@@ -0,0 +1,189 @@
+package swagno3
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-swagno/swagno/v3/components/endpoint"
+	"github.com/go-swagno/swagno/v3/components/http/response"
+	"github.com/go-swagno/swagno/v3/components/parameter"
+)
+
+// ComponentRefError is returned by ToJson (and panicked by MustToJson) when endpoints or
+// responses reference parameters, responses, request bodies or headers by a name that
+// was never registered, which would leave a dangling '$ref' in the document, or when a
+// parameter could not be registered.
+type ComponentRefError struct {
+	// Errors describes each rejected registration, followed by each unregistered
+	// reference in the order the endpoints were added.
+	Errors []error
+}
+
+func (e *ComponentRefError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return "swagno: unregistered components: " + strings.Join(messages, "; ")
+}
+
+// RegisterParameter registers a reusable parameter under components/parameters, which
+// endpoints reference by name with endpoint.WithParamRefs, e.g.
+//
+//	openapi.RegisterParameter("page", parameter.IntParam("page", parameter.Query))
+//
+// Form parameters are documented as properties of the request body rather than
+// parameters, so they are not registered and ToJson returns a *ComponentRefError.
+func (o *OpenAPI) RegisterParameter(name string, p *parameter.Parameter) {
+	if p.Location() == parameter.Form {
+		o.parameterErrors = append(o.parameterErrors, fmt.Errorf("parameter %q is a form parameter, which is documented in the request body", name))
+		return
+	}
+	if o.parameters == nil {
+		o.parameters = make(map[string]*parameter.Parameter)
+	}
+	o.parameters[name] = p
+}
+
+// RegisterResponse registers a reusable response under components/responses, which
+// endpoints reference by name with endpoint.WithResponseRefs. The response is documented
+// under its return code wherever it is referenced, e.g.
+//
+//	openapi.RegisterResponse("Unauthorized", response.New(ErrorResponse{}, "401", "Unauthorized"))
+func (o *OpenAPI) RegisterResponse(name string, resp response.Response) {
+	if o.responses == nil {
+		o.responses = make(map[string]response.Response)
+	}
+	o.responses[name] = resp
+}
+
+// RegisterRequestBody registers a reusable request body under components/requestBodies,
+// which endpoints reference by name with endpoint.WithBodyRef. The body and its options
+// are those of endpoint.WithBody.
+func (o *OpenAPI) RegisterRequestBody(name string, body interface{}, opts ...endpoint.BodyOption) {
+	if o.requestBodies == nil {
+		o.requestBodies = make(map[string]*endpoint.EndPoint)
+	}
+	o.requestBodies[name] = endpoint.New("", "", endpoint.WithBody(body, opts...))
+}
+
+// RegisterHeader registers a reusable header under components/headers, which responses
+// reference by name with response.CustomResponse.WithHeaderRefs.
+func (o *OpenAPI) RegisterHeader(name string, header ComponentHeader) {
+	if o.Components == nil {
+		o.Components = &Components{}
+	}
+	if o.Components.Headers == nil {
+		o.Components.Headers = make(map[string]ComponentHeader)
+	}
+	o.Components.Headers[name] = header
+}
+
+// componentRef returns the '$ref' of the component named name in the components section.
+func componentRef(section, name string) string {
+	return "#/components/" + section + "/" + name
+}
+
+// responseNames returns the names of the registered responses in order, so their
+// schemas and errors are generated deterministically.
+func (o *OpenAPI) responseNames() []string {
+	names := make([]string, 0, len(o.responses))
+	for name := range o.responses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// requestBodyNames returns the names of the registered request bodies in order.
+func (o *OpenAPI) requestBodyNames() []string {
+	names := make([]string, 0, len(o.requestBodies))
+	for name := range o.requestBodies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// generateComponents adds the registered parameters, responses and request bodies to
+// the components of the document, and returns an error for each header referenced by
+// the registered responses that is not registered.
+func (o *OpenAPI) generateComponents(responseGenerator *response.ResponseGenerator) []error {
+	if o.Components == nil {
+		o.Components = &Components{}
+	}
+
+	errs := append([]error(nil), o.parameterErrors...)
+	for name, param := range o.parameters {
+		if o.Components.Parameters == nil {
+			o.Components.Parameters = make(map[string]parameter.JsonParameter)
+		}
+		o.Components.Parameters[name] = o.paramJson(param)
+	}
+	for _, name := range o.responseNames() {
+		if o.Components.Responses == nil {
+			o.Components.Responses = make(map[string]endpoint.JsonResponse)
+		}
+		resp := o.responses[name]
+		o.Components.Responses[name] = appendResponses(map[string]endpoint.JsonResponse{}, []response.Response{resp}, responseGenerator)[resp.ReturnCode()]
+		errs = append(errs, o.headerRefErrors(fmt.Sprintf("response %q", name), resp)...)
+	}
+	for _, name := range o.requestBodyNames() {
+		if o.Components.RequestBodies == nil {
+			o.Components.RequestBodies = make(map[string]endpoint.RequestBody)
+		}
+		e := o.requestBodies[name]
+		o.Components.RequestBodies[name] = newRequestBody(e.BodyJsonParameterWith(responseGenerator), e.AsJson().Consume)
+	}
+	return errs
+}
+
+// refParams returns the registered parameters e references, and an error for each
+// reference to an unregistered parameter.
+func (o *OpenAPI) refParams(e *endpoint.EndPoint, path string) ([]*parameter.Parameter, []error) {
+	var params []*parameter.Parameter
+	var errs []error
+	for _, name := range e.ParamRefs() {
+		param, ok := o.parameters[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s %s: parameter %q is not registered", e.Method(), path, name))
+			continue
+		}
+		params = append(params, param)
+	}
+	return params, errs
+}
+
+// appendResponseRefs documents the registered responses e references under their return
+// codes in responses, and returns an error for each reference to an unregistered response.
+func (o *OpenAPI) appendResponseRefs(responses map[string]endpoint.JsonResponse, e *endpoint.EndPoint, path string) []error {
+	var errs []error
+	for _, name := range e.ResponseRefs() {
+		resp, ok := o.responses[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s %s: response %q is not registered", e.Method(), path, name))
+			continue
+		}
+		responses[resp.ReturnCode()] = endpoint.JsonResponse{Ref: componentRef("responses", name)}
+	}
+	return errs
+}
+
+// headerRefErrors returns an error for each header the responses reference that is not
+// registered, described as being referenced from where.
+func (o *OpenAPI) headerRefErrors(where string, responses ...response.Response) []error {
+	var errs []error
+	for _, resp := range responses {
+		custom, ok := resp.(response.CustomResponse)
+		if !ok {
+			continue
+		}
+		for _, name := range custom.HeaderRefs() {
+			if _, ok := o.Components.Headers[name]; !ok {
+				errs = append(errs, fmt.Errorf("%s: header %q is not registered", where, name))
+			}
+		}
+	}
+	return errs
+}
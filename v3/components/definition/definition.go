@@ -3,7 +3,7 @@ package definition
 import (
 	"fmt"
 	"reflect"
-	"strings"
+	"sort"
 
 	"github.com/go-swagno/swagno/v3/components/extensions"
 	"github.com/go-swagno/swagno/v3/components/fields"
@@ -13,45 +13,45 @@ import (
 // Schema represents an OpenAPI 3.0 schema definition for a type.
 // See: https://spec.openapis.org/oas/v3.0.3#schema-object
 type Schema struct {
-	Type                 string                    `json:"type,omitempty"`
-	Format               string                    `json:"format,omitempty"`
-	Title                string                    `json:"title,omitempty"`
-	Description          string                    `json:"description,omitempty"`
-	Default              interface{}               `json:"default,omitempty"`
-	Example              interface{}               `json:"example,omitempty"`
-	Examples             []interface{}             `json:"examples,omitempty"`
-	Enum                 []interface{}             `json:"enum,omitempty"`
-	Const                interface{}               `json:"const,omitempty"`
-	Properties           map[string]SchemaProperty `json:"properties,omitempty"`
-	AdditionalProperties interface{}               `json:"additionalProperties,omitempty"`
-	Required             []string                  `json:"required,omitempty"`
-	Items                *SchemaItems              `json:"items,omitempty"`
-	AllOf                []*Schema                 `json:"allOf,omitempty"`
-	OneOf                []*Schema                 `json:"oneOf,omitempty"`
-	AnyOf                []*Schema                 `json:"anyOf,omitempty"`
-	Not                  *Schema                   `json:"not,omitempty"`
-	MinLength            *int64                    `json:"minLength,omitempty"`
-	MaxLength            *int64                    `json:"maxLength,omitempty"`
-	Pattern              string                    `json:"pattern,omitempty"`
-	Minimum              *float64                  `json:"minimum,omitempty"`
-	Maximum              *float64                  `json:"maximum,omitempty"`
-	ExclusiveMinimum     bool                      `json:"exclusiveMinimum,omitempty"`
-	ExclusiveMaximum     bool                      `json:"exclusiveMaximum,omitempty"`
-	MultipleOf           *float64                  `json:"multipleOf,omitempty"`
-	MinItems             *int64                    `json:"minItems,omitempty"`
-	MaxItems             *int64                    `json:"maxItems,omitempty"`
-	UniqueItems          bool                      `json:"uniqueItems,omitempty"`
-	MinProperties        *int64                    `json:"minProperties,omitempty"`
-	MaxProperties        *int64                    `json:"maxProperties,omitempty"`
-	Nullable             bool                      `json:"nullable,omitempty"`
-	Discriminator        *Discriminator            `json:"discriminator,omitempty"`
-	ReadOnly             bool                      `json:"readOnly,omitempty"`
-	WriteOnly            bool                      `json:"writeOnly,omitempty"`
-	XML                  *XML                      `json:"xml,omitempty"`
-	ExternalDocs         *ExternalDocs             `json:"externalDocs,omitempty"`
-	Deprecated           bool                      `json:"deprecated,omitempty"`
-	Ref                  string                    `json:"$ref,omitempty"`
-	Extensions           extensions.Extensions     `json:"-"`
+	Type                 string                `json:"type,omitempty"`
+	Format               string                `json:"format,omitempty"`
+	Title                string                `json:"title,omitempty"`
+	Description          string                `json:"description,omitempty"`
+	Default              interface{}           `json:"default,omitempty"`
+	Example              interface{}           `json:"example,omitempty"`
+	Examples             []interface{}         `json:"examples,omitempty"`
+	Enum                 []interface{}         `json:"enum,omitempty"`
+	Const                interface{}           `json:"const,omitempty"`
+	Properties           Properties            `json:"properties,omitempty"`
+	AdditionalProperties interface{}           `json:"additionalProperties,omitempty"`
+	Required             []string              `json:"required,omitempty"`
+	Items                *SchemaItems          `json:"items,omitempty"`
+	AllOf                []*Schema             `json:"allOf,omitempty"`
+	OneOf                []*Schema             `json:"oneOf,omitempty"`
+	AnyOf                []*Schema             `json:"anyOf,omitempty"`
+	Not                  *Schema               `json:"not,omitempty"`
+	MinLength            *int64                `json:"minLength,omitempty"`
+	MaxLength            *int64                `json:"maxLength,omitempty"`
+	Pattern              string                `json:"pattern,omitempty"`
+	Minimum              *float64              `json:"minimum,omitempty"`
+	Maximum              *float64              `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool                  `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool                  `json:"exclusiveMaximum,omitempty"`
+	MultipleOf           *float64              `json:"multipleOf,omitempty"`
+	MinItems             *int64                `json:"minItems,omitempty"`
+	MaxItems             *int64                `json:"maxItems,omitempty"`
+	UniqueItems          bool                  `json:"uniqueItems,omitempty"`
+	MinProperties        *int64                `json:"minProperties,omitempty"`
+	MaxProperties        *int64                `json:"maxProperties,omitempty"`
+	Nullable             bool                  `json:"nullable,omitempty"`
+	Discriminator        *Discriminator        `json:"discriminator,omitempty"`
+	ReadOnly             bool                  `json:"readOnly,omitempty"`
+	WriteOnly            bool                  `json:"writeOnly,omitempty"`
+	XML                  *XML                  `json:"xml,omitempty"`
+	ExternalDocs         *ExternalDocs         `json:"externalDocs,omitempty"`
+	Deprecated           bool                  `json:"deprecated,omitempty"`
+	Ref                  string                `json:"$ref,omitempty"`
+	Extensions           extensions.Extensions `json:"-"`
 }
 
 func (s Schema) MarshalJSON() ([]byte, error) {
@@ -59,31 +59,44 @@ func (s Schema) MarshalJSON() ([]byte, error) {
 	return extensions.Merge(alias(s), s.Extensions)
 }
 
+// SchemaProvider is implemented by types that describe their own schema, such as
+// custom marshalers for enums, money types or union wrappers. The returned
+// Schema is used as-is instead of the one reflection would produce.
+// Both value and pointer receivers are supported.
+type SchemaProvider interface {
+	SwaggerSchema() Schema
+}
+
 // SchemaProperty defines the details of a property within a Schema,
 // which may include its type, format, reference to another schema, among others.
 type SchemaProperty struct {
-	Type        string                    `json:"type,omitempty"`
-	Format      string                    `json:"format,omitempty"`
-	Ref         string                    `json:"$ref,omitempty"`
-	Items       *SchemaItems              `json:"items,omitempty"`
-	Example     interface{}               `json:"example,omitempty"`
-	Description string                    `json:"description,omitempty"`
-	Default     interface{}               `json:"default,omitempty"`
-	Enum        []interface{}             `json:"enum,omitempty"`
-	MinLength   *int64                    `json:"minLength,omitempty"`
-	MaxLength   *int64                    `json:"maxLength,omitempty"`
-	Pattern     string                    `json:"pattern,omitempty"`
-	Minimum     *float64                  `json:"minimum,omitempty"`
-	Maximum     *float64                  `json:"maximum,omitempty"`
-	MinItems    *int64                    `json:"minItems,omitempty"`
-	MaxItems    *int64                    `json:"maxItems,omitempty"`
-	UniqueItems bool                      `json:"uniqueItems,omitempty"`
-	MultipleOf  *float64                  `json:"multipleOf,omitempty"`
-	Nullable    bool                      `json:"nullable,omitempty"`
-	ReadOnly    bool                      `json:"readOnly,omitempty"`
-	WriteOnly   bool                      `json:"writeOnly,omitempty"`
-	Deprecated  bool                      `json:"deprecated,omitempty"`
-	Properties  map[string]SchemaProperty `json:"properties,omitempty"`
+	Type             string        `json:"type,omitempty"`
+	Format           string        `json:"format,omitempty"`
+	Ref              string        `json:"$ref,omitempty"`
+	Items            *SchemaItems  `json:"items,omitempty"`
+	Example          interface{}   `json:"example,omitempty"`
+	Description      string        `json:"description,omitempty"`
+	Default          interface{}   `json:"default,omitempty"`
+	Enum             []interface{} `json:"enum,omitempty"`
+	MinLength        *int64        `json:"minLength,omitempty"`
+	MaxLength        *int64        `json:"maxLength,omitempty"`
+	Pattern          string        `json:"pattern,omitempty"`
+	Minimum          *float64      `json:"minimum,omitempty"`
+	Maximum          *float64      `json:"maximum,omitempty"`
+	ExclusiveMinimum bool          `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum bool          `json:"exclusiveMaximum,omitempty"`
+	MinItems         *int64        `json:"minItems,omitempty"`
+	MaxItems         *int64        `json:"maxItems,omitempty"`
+	UniqueItems      bool          `json:"uniqueItems,omitempty"`
+	MultipleOf       *float64      `json:"multipleOf,omitempty"`
+	Nullable         bool          `json:"nullable,omitempty"`
+	ReadOnly         bool          `json:"readOnly,omitempty"`
+	WriteOnly        bool          `json:"writeOnly,omitempty"`
+	Deprecated       bool          `json:"deprecated,omitempty"`
+	Properties       Properties    `json:"properties,omitempty"`
+	Required         []string      `json:"required,omitempty"`
+
+	AdditionalProperties *SchemaProperty `json:"additionalProperties,omitempty"`
 
 	// keep this info to fill Required fields later
 	IsRequired bool `json:"-"`
@@ -92,10 +105,18 @@ type SchemaProperty struct {
 // SchemaItems specifies the type or reference of array items when
 // the 'type' of SchemaProperty is set to 'array'.
 type SchemaItems struct {
-	Type   string       `json:"type,omitempty"`
-	Ref    string       `json:"$ref,omitempty"`
-	Items  *SchemaItems `json:"items,omitempty"`
-	Format string       `json:"format,omitempty"`
+	Type     string        `json:"type,omitempty"`
+	Ref      string        `json:"$ref,omitempty"`
+	Items    *SchemaItems  `json:"items,omitempty"`
+	Format   string        `json:"format,omitempty"`
+	Enum     []interface{} `json:"enum,omitempty"`
+	Minimum  *float64      `json:"minimum,omitempty"`
+	MinItems *int64        `json:"minItems,omitempty"`
+	MaxItems *int64        `json:"maxItems,omitempty"`
+
+	Properties           Properties      `json:"properties,omitempty"`
+	Required             []string        `json:"required,omitempty"`
+	AdditionalProperties *SchemaProperty `json:"additionalProperties,omitempty"`
 }
 
 // Discriminator represents a discriminator object for polymorphism
@@ -144,11 +165,44 @@ type DefinitionGenerator struct {
 	// HidePackageName, when true, strips the leading package qualifier from
 	// schema names and $ref values (e.g. "models.MyStruct" -> "MyStruct").
 	HidePackageName bool
-	// DefinitionTypeNames records, per stripped schema name, the set of full
+	// DefinitionTypeNames records, per schema name, the set of full
 	// (package-qualified) type names that produced it. It is used to detect
-	// collisions when HidePackageName is enabled. The map is shared across
-	// generator instances so it accumulates across all schemas of a document.
+	// collisions caused by HidePackageName or the naming of generic types. The map
+	// is shared across generator instances so it accumulates across all schemas
+	// of a document.
 	DefinitionTypeNames map[string]map[string]struct{}
+	// Types holds the user registered type mappings. Registered types, as well as
+	// the built-in mappings for standard library types, are documented inline
+	// instead of being reflected into a schema.
+	Types fields.Types
+	// Enums holds the user registered enum values. Properties and array items of
+	// these types, or of types implementing fields.Enumer, carry an 'enum'.
+	Enums fields.Enums
+	// EnumSchemas, when true, documents enum types as reusable component schemas
+	// that their properties and array items reference.
+	EnumSchemas bool
+	// Interfaces holds the registered implementations of interface types. Fields of
+	// these types reference a oneOf schema with a discriminator, other interface
+	// fields are documented as unconstrained values.
+	Interfaces fields.Interfaces
+	// NameStrategy names the schemas and their references. When nil, HidePackageName
+	// selects between fields.QualifiedNames and fields.UnqualifiedNames.
+	NameStrategy fields.NameStrategy
+	// EmbeddedAllOf, when true, documents structs with embedded structs as an allOf
+	// of references to the embedded structs' schemas and their own fields, instead
+	// of flattening the promoted fields into the schema.
+	EmbeddedAllOf bool
+	// ExampleErrors records, by message, the errors converting 'example' tags to the
+	// type of their field. Like DefinitionTypeNames, the map is shared across generator
+	// instances so it accumulates across all schemas of a document.
+	ExampleErrors map[string]error
+	// Comments holds the doc comments of Go types and struct fields, which describe
+	// the schemas and properties without an explicit description.
+	Comments fields.Comments
+	// visited holds the struct types whose schemas are created, or being created,
+	// by the current CreateDefinition call, so recursive types are referenced
+	// instead of being reflected again.
+	visited map[reflect.Type]bool
 }
 
 // NewDefinitionGenerator is a constructor function that initializes
@@ -161,11 +215,29 @@ func NewDefinitionGenerator(schemaMap map[string]Schema, hidePackageName bool, d
 	}
 }
 
-// recordName tracks that the stripped schema name shortName was produced by the
-// full (package-qualified) type name fullName. It is a no-op unless HidePackageName
-// is enabled, since collisions can only arise from stripping.
+// name returns the name of the schema of t.
+func (g DefinitionGenerator) name(t reflect.Type) string {
+	return fields.DefinitionName(t, g.NameStrategy, g.HidePackageName)
+}
+
+// example returns the example of field converted to the field's type. Examples that
+// cannot be converted are recorded in ExampleErrors and documented as the raw string.
+func (g DefinitionGenerator) example(field reflect.StructField) interface{} {
+	value, err := fields.Example(field, g.Types)
+	if err != nil {
+		if g.ExampleErrors != nil {
+			g.ExampleErrors[err.Error()] = err
+		}
+		return field.Tag.Get("example")
+	}
+	return value
+}
+
+// recordName tracks that the schema name shortName was produced by the full
+// (package-qualified) type name fullName. Names are shortened by HidePackageName
+// and by the naming of generic types, so distinct types may share a name.
 func (g DefinitionGenerator) recordName(shortName, fullName string) {
-	if !g.HidePackageName || g.DefinitionTypeNames == nil {
+	if g.DefinitionTypeNames == nil {
 		return
 	}
 	set, ok := g.DefinitionTypeNames[shortName]
@@ -178,303 +250,661 @@ func (g DefinitionGenerator) recordName(shortName, fullName string) {
 
 // CreateDefinition analyzes the type of the provided value 't' and adds a corresponding Schema to the generator's Schemas map.
 func (g DefinitionGenerator) CreateDefinition(t interface{}) {
-	properties := make(map[string]SchemaProperty)
+	properties := Properties{}
 	fullName := fmt.Sprintf("%T", t)
-	definitionName := fields.RefName(fullName, g.HidePackageName)
+	definitionName := g.name(reflect.TypeOf(t))
 
 	reflectReturn := reflect.TypeOf(t)
-	switch reflectReturn.Kind() {
-	case reflect.Slice:
-		reflectReturn = reflectReturn.Elem()
-		if reflectReturn.Kind() == reflect.Struct {
-			properties = g.createStructDefinitions(reflectReturn)
+	if reflectReturn == nil {
+		return
+	}
+	if _, ok := g.Types.Lookup(reflectReturn); ok {
+		return // registered types are documented inline
+	}
+	if provider, ok := schemaProvider(reflectReturn); ok {
+		g.recordName(definitionName, fullName)
+		g.Schemas[definitionName] = provider.SwaggerSchema()
+		return
+	}
+	if enum, ok := g.Enums.Lookup(reflectReturn); ok && g.EnumSchemas {
+		schema := Schema{
+			Type:   fields.Type(reflectReturn.Kind().String()),
+			Format: fields.Format(reflectReturn.Kind()),
+			Enum:   enum.Values,
+		}
+		schema.Description, _ = g.Comments.Type(reflectReturn)
+		if len(enum.VarNames) > 0 {
+			schema.Extensions = extensions.Extensions{"x-enum-varnames": enum.VarNames}
 		}
-		definitionName, _ = strings.CutPrefix(definitionName, "[]")
-		fullName, _ = strings.CutPrefix(fullName, "[]")
+		g.recordName(definitionName, fullName)
+		g.Schemas[definitionName] = schema
+		return
+	}
+	switch reflectReturn.Kind() {
+	case reflect.Pointer:
+		g.CreateDefinition(reflect.New(reflectReturn.Elem()).Elem().Interface())
+		return
+	case reflect.Slice, reflect.Array, reflect.Map:
+		// collections are documented inline, only the schemas of their elements are needed
+		g.typeProperty(reflectReturn)
+		return
 	case reflect.Struct:
 		if reflectReturn == reflect.TypeOf(response.CustomResponse{}) {
 			// if CustomResponseType, use Model struct in it
 			g.CreateDefinition(t.(response.CustomResponse).Model)
 			return
 		}
-		properties = g.createStructDefinitions(reflectReturn)
+		if reflectReturn.Name() == "" {
+			// anonymous structs are documented inline
+			g.typeProperty(reflectReturn)
+			return
+		}
+		if g.visited == nil {
+			g.visited = map[reflect.Type]bool{}
+		}
+		if g.visited[reflectReturn] {
+			return // already created, or being created by a recursive reference
+		}
+		g.visited[reflectReturn] = true
+		jsonFields := fields.JSONFields(reflectReturn)
+		if embedded, own := composedFields(reflectReturn, jsonFields); g.EmbeddedAllOf && len(embedded) > 0 {
+			g.recordName(definitionName, fullName)
+			schema := g.composedSchema(embedded, g.createStructDefinitions(own))
+			schema.Description, _ = g.Comments.Type(reflectReturn)
+			g.Schemas[definitionName] = schema
+			return
+		}
+		properties = g.createStructDefinitions(jsonFields)
+	default:
+		return // primitives are documented inline
 	}
 
-	// merge embedded struct fields with other fields
-	g.mergeEmbeddedStructFields(properties)
-
-	// delete empty json tags
-	delete(properties, "")
-
 	g.recordName(definitionName, fullName)
+	description, _ := g.Comments.Type(reflectReturn)
 	g.Schemas[definitionName] = Schema{
-		Type:       "object",
-		Properties: properties,
-		Required:   g.findRequiredFields(properties),
+		Type:        "object",
+		Description: description,
+		Properties:  properties,
+		Required:    g.findRequiredFields(properties),
 	}
 }
 
-func (g DefinitionGenerator) mergeEmbeddedStructFields(properties map[string]SchemaProperty) {
-	for k, v := range properties {
-		if k == "" && v.Ref != "" { // identify embedded structs
-			embeddedModelName, _ := strings.CutPrefix(v.Ref, "#/components/schemas/")
-			if schema, ok := g.Schemas[embeddedModelName]; ok {
-				for propName, propValue := range schema.Properties {
-					properties[propName] = propValue
-				}
-				delete(properties, "")
-			}
+// composedFields splits the fields encoding/json serializes for structType, as returned
+// by fields.JSONFields, into the embedded structs that are referenced from an allOf and
+// the fields documented as its own properties. An embedded struct is only referenced
+// when all of its fields are promoted: when structType shadows or conflicts with any of
+// them, the fields it still promotes are flattened into the own properties instead, so
+// the schema documents the same object as encoding/json.
+func composedFields(structType reflect.Type, jsonFields []fields.Field) ([]reflect.Type, []fields.Field) {
+	promoted := map[int]int{}
+	for _, field := range jsonFields {
+		if len(field.Index) > 1 {
+			promoted[field.Index[0]]++
+		}
+	}
+	embedded := []reflect.Type{}
+	referenced := map[int]bool{}
+	for i := 0; i < structType.NumField(); i++ {
+		t, ok := fields.EmbeddedStruct(structType.Field(i))
+		if ok && promoted[i] == len(fields.JSONFields(t)) {
+			embedded = append(embedded, t)
+			referenced[i] = true
 		}
 	}
+	own := []fields.Field{}
+	for _, field := range jsonFields {
+		if !referenced[field.Index[0]] {
+			own = append(own, field)
+		}
+	}
+	return embedded, own
+}
+
+// composedSchema returns the schema of a struct with embedded structs when
+// EmbeddedAllOf is set: an allOf of references to the schemas of the embedded
+// structs, followed by the struct's own fields.
+func (g DefinitionGenerator) composedSchema(embedded []reflect.Type, properties Properties) Schema {
+	schema := Schema{AllOf: []*Schema{}}
+	for _, t := range embedded {
+		g.CreateDefinition(reflect.New(t).Elem().Interface())
+		schema.AllOf = append(schema.AllOf, &Schema{Ref: fmt.Sprintf("#/components/schemas/%s", g.name(t))})
+	}
+	if len(properties) > 0 {
+		schema.AllOf = append(schema.AllOf, &Schema{
+			Type:       "object",
+			Properties: properties,
+			Required:   g.findRequiredFields(properties),
+		})
+	}
+	return schema
 }
 
-func (g DefinitionGenerator) findRequiredFields(properties map[string]SchemaProperty) []string {
+func (g DefinitionGenerator) findRequiredFields(properties Properties) []string {
 	requiredFields := []string{}
-	for k, v := range properties {
-		if v.IsRequired {
-			requiredFields = append(requiredFields, k)
+	for _, property := range properties {
+		if property.Schema.IsRequired {
+			requiredFields = append(requiredFields, property.Name)
 		}
 	}
 	return requiredFields
 }
 
-func (g DefinitionGenerator) createStructDefinitions(structType reflect.Type) map[string]SchemaProperty {
-	properties := make(map[string]SchemaProperty)
-	for i := 0; i < structType.NumField(); i++ {
-		field := structType.Field(i)
+// createStructDefinitions returns the properties of the fields encoding/json serializes,
+// as returned by fields.JSONFields, in the same order.
+func (g DefinitionGenerator) createStructDefinitions(jsonFields []fields.Field) Properties {
+	properties := Properties{}
+	for _, jsonField := range jsonFields {
+		field := jsonField.StructField
 		fieldType := fields.Type(field.Type.Kind().String())
-		fieldJsonTag := fields.JsonTag(field)
+		fieldJsonTag := jsonField.Name
 
-		// skip ignored tags
-		if fieldJsonTag == "-" {
+		// skip for function and channel types, and pointers to them
+		if kind := fields.Deref(field.Type).Kind(); kind == reflect.Func || kind == reflect.Chan {
 			continue
 		}
 
-		// skip for function and channel types
-		if fieldType == "func" || fieldType == "chan" {
+		// registered types are documented as-is instead of being reflected
+		if property, ok := g.registeredProperty(field); ok {
+			properties.Set(fieldJsonTag, applyTags(property, field))
+			continue
+		}
+
+		// types describing their own schema are referenced by their schema
+		if property, ok := g.providedProperty(field); ok {
+			properties.Set(fieldJsonTag, property)
+			continue
+		}
+
+		// anonymous structs have no name to reference and are documented inline
+		if fields.AnonymousStruct(field.Type) {
+			property := g.typeProperty(field.Type)
+			property.IsRequired = g.isRequired(field)
+			if field.Type.Kind() == reflect.Pointer {
+				property.IsRequired = fields.IsRequired(field)
+				property.Nullable = true
+			}
+			property.Example = g.example(field)
+			property.Description = fields.DescriptionTag(field)
+			properties.Set(fieldJsonTag, applyTags(property, field))
+			continue
+		}
+
+		// named types with a fixed set of values carry their enum
+		if property, ok := g.enumProperty(field); ok {
+			properties.Set(fieldJsonTag, applyTags(property, field))
 			continue
 		}
 
 		// if item type is array, create Schema for array element type
 		switch fieldType {
 		case "array":
-			if field.Type.Elem().Kind() == reflect.Pointer { // []*type
-				if field.Type.Elem().Elem().Kind() == reflect.Struct { // []*struct
-					properties[fieldJsonTag] = SchemaProperty{
-						Type: fieldType,
-						Items: &SchemaItems{
-							Ref: fmt.Sprintf("#/components/schemas/%s", fields.RefName(field.Type.Elem().Elem().String(), g.HidePackageName)),
-						},
-						IsRequired:  g.isRequired(field),
-						Nullable:    field.Type.Kind() == reflect.Pointer,
-						Example:     fields.ExampleTag(field),
-						Description: fields.DescriptionTag(field),
-					}
-					if structType == field.Type.Elem().Elem() {
-						continue // prevent recursion
-					}
-					g.CreateDefinition(reflect.New(field.Type.Elem().Elem()).Elem().Interface())
-				} else { // []*other
-					itemType := fields.Type(field.Type.Elem().Elem().Kind().String())
-					properties[fieldJsonTag] = SchemaProperty{
-						Type: fieldType,
-						Items: &SchemaItems{
-							Type: itemType,
-						},
-						IsRequired:  g.isRequired(field),
-						Nullable:    field.Type.Kind() == reflect.Pointer,
-						Example:     fields.ExampleTag(field),
-						Description: fields.DescriptionTag(field),
-					}
-				}
-			} else if field.Type.Elem().Kind() == reflect.Struct { // []struct
-				properties[fieldJsonTag] = SchemaProperty{
-					Type: fieldType,
-					Items: &SchemaItems{
-						Ref: fmt.Sprintf("#/components/schemas/%s", fields.RefName(field.Type.Elem().String(), g.HidePackageName)),
-					},
-					IsRequired:  g.isRequired(field),
-					Example:     fields.ExampleTag(field),
-					Description: fields.DescriptionTag(field),
-				}
-				if structType == field.Type.Elem() {
-					continue // prevent recursion
-				}
-				g.CreateDefinition(reflect.New(field.Type.Elem()).Elem().Interface())
-			} else { // []other
-				properties[fieldJsonTag] = SchemaProperty{
-					Type: fieldType,
-					Items: &SchemaItems{
-						Type: fields.Type(field.Type.Elem().Kind().String()),
-					},
-					IsRequired:  g.isRequired(field),
-					Example:     fields.ExampleTag(field),
-					Description: fields.DescriptionTag(field),
-				}
-			}
+			property := g.typeProperty(field.Type)
+			property.IsRequired = g.isRequired(field)
+			property.Example = g.example(field)
+			property.Description = fields.DescriptionTag(field)
+			properties.Set(fieldJsonTag, property)
 
 		case "struct":
-			isRequiredField := g.isRequired(field)
-			if field.Type.String() == "time.Time" {
-				properties[fieldJsonTag] = g.timeProperty(field, isRequiredField)
-			} else if field.Type.String() == "time.Duration" {
-				properties[fieldJsonTag] = g.durationProperty(field, isRequiredField)
-			} else {
-				properties[fieldJsonTag] = SchemaProperty{
-					Ref:         fmt.Sprintf("#/components/schemas/%s", fields.RefName(field.Type.String(), g.HidePackageName)),
-					IsRequired:  isRequiredField,
-					Example:     fields.ExampleTag(field),
-					Description: fields.DescriptionTag(field),
-				}
-				g.CreateDefinition(reflect.New(field.Type).Elem().Interface())
-			}
+			properties.Set(fieldJsonTag, SchemaProperty{
+				Ref:         fmt.Sprintf("#/components/schemas/%s", g.name(field.Type)),
+				IsRequired:  g.isRequired(field),
+				Example:     g.example(field),
+				Description: fields.DescriptionTag(field),
+			})
+			g.CreateDefinition(reflect.New(field.Type).Elem().Interface())
 
 		case "ptr":
-			if field.Type.Elem() == structType { // prevent recursion
-				properties[fieldJsonTag] = SchemaProperty{
-					Example:     fmt.Sprintf("Recursive Type: %s", field.Type.Elem().String()),
-					Description: fields.DescriptionTag(field),
-				}
-				continue
-			}
 			if field.Type.Elem().Kind() == reflect.Struct {
-				if field.Type.Elem().String() == "time.Time" {
-					properties[fieldJsonTag] = g.timeProperty(field, false)
-				} else if field.Type.String() == "time.Duration" {
-					properties[fieldJsonTag] = g.durationProperty(field, false)
-				} else {
-					properties[fieldJsonTag] = g.refProperty(field, false)
-					g.CreateDefinition(reflect.New(field.Type.Elem()).Elem().Interface())
-				}
-			} else if field.Type.Elem().Kind() == reflect.Array || field.Type.Elem().Kind() == reflect.Slice {
-				if field.Type.Elem().Elem().Kind() == reflect.Struct {
-					properties[fieldJsonTag] = SchemaProperty{
-						Type: fields.Type(field.Type.Elem().Kind().String()),
-						Items: &SchemaItems{
-							Ref: fmt.Sprintf("#/components/schemas/%s", fields.RefName(field.Type.Elem().Elem().String(), g.HidePackageName)),
-						},
-						Nullable:    true,
-						Example:     fields.ExampleTag(field),
-						Description: fields.DescriptionTag(field),
-					}
-					if structType == field.Type.Elem().Elem() {
-						continue // prevent recursion
-					}
-					g.CreateDefinition(reflect.New(field.Type.Elem().Elem()).Elem().Interface())
-				} else {
-					properties[fieldJsonTag] = SchemaProperty{
-						Type: fields.Type(field.Type.Elem().Kind().String()),
-						Items: &SchemaItems{
-							Type: fields.Type(field.Type.Elem().Elem().Kind().String()),
-						},
-						Nullable:    true,
-						Example:     fields.ExampleTag(field),
-						Description: fields.DescriptionTag(field),
-					}
-				}
+				properties.Set(fieldJsonTag, g.refProperty(field, fields.IsRequired(field)))
+				g.CreateDefinition(reflect.New(field.Type.Elem()).Elem().Interface())
 			} else {
-				properties[fieldJsonTag] = SchemaProperty{
-					Type:        fields.Type(field.Type.Elem().Kind().String()),
-					Nullable:    true,
-					Example:     fields.ExampleTag(field),
-					Description: fields.DescriptionTag(field),
-				}
+				// other pointers are documented like their element, e.g. interfaces, enums or collections
+				property := g.typeProperty(field.Type.Elem())
+				property.IsRequired = fields.IsRequired(field)
+				property.Nullable = true
+				property.Example = g.example(field)
+				property.Description = fields.DescriptionTag(field)
+				properties.Set(fieldJsonTag, property)
 			}
 
 		case "map":
-			name := fmt.Sprintf("%s.%s", fields.RefName(structType.String(), g.HidePackageName), fieldJsonTag)
-			g.recordName(name, fmt.Sprintf("%s.%s", structType.String(), fieldJsonTag))
-			mapKeyType := field.Type.Key()
-			mapValueType := field.Type.Elem()
-			if mapValueType.Kind() == reflect.Ptr {
-				mapValueType = mapValueType.Elem()
-			}
-			properties[fieldJsonTag] = SchemaProperty{
-				Ref:         fmt.Sprintf("#/components/schemas/%s", name),
-				Example:     fields.ExampleTag(field),
-				Description: fields.DescriptionTag(field),
-			}
-			if mapValueType.Kind() == reflect.Struct {
-				g.Schemas[name] = Schema{
-					Type: "object",
-					Properties: map[string]SchemaProperty{
-						fields.Type(mapKeyType.String()): {
-							Ref:         fmt.Sprintf("#/components/schemas/%s", fields.RefName(mapValueType.String(), g.HidePackageName)),
-							Example:     fields.ExampleTag(field),
-							Description: fields.DescriptionTag(field),
-						},
-					},
-				}
-			} else {
-				g.Schemas[name] = Schema{
-					Type: "object",
-					Properties: map[string]SchemaProperty{
-						fields.Type(mapKeyType.String()): {
-							Type:        fields.Type(mapValueType.String()),
-							Example:     fields.ExampleTag(field),
-							Description: fields.DescriptionTag(field),
-						},
-					},
-				}
-			}
+			property := g.typeProperty(field.Type)
+			property.IsRequired = g.isRequired(field)
+			property.Example = g.example(field)
+			property.Description = fields.DescriptionTag(field)
+			properties.Set(fieldJsonTag, property)
 
 		case "interface":
-			// TODO: Find a way to get real model of interface{}
-			properties[fieldJsonTag] = SchemaProperty{
-				Type:        "Ambiguous Type: interface{}",
-				IsRequired:  g.isRequired(field),
-				Example:     fields.ExampleTag(field),
-				Description: fields.DescriptionTag(field),
-			}
+			properties.Set(fieldJsonTag, g.interfaceProperty(field))
 
 		default:
-			properties[fieldJsonTag] = g.defaultProperty(field)
+			properties.Set(fieldJsonTag, g.defaultProperty(field))
 
 		}
+
+		if property, ok := properties.Get(fieldJsonTag); ok {
+			properties.Set(fieldJsonTag, applyTags(property, field))
+		}
+
+		// values encoded by the ',string' option are JSON strings
+		if property, ok := properties.Get(fieldJsonTag); ok && jsonField.Quoted {
+			properties.Set(fieldJsonTag, quotedProperty(property))
+		}
 	}
 
+	// 'desc' tags, then doc comments, describe the properties without a description
+	for _, jsonField := range jsonFields {
+		property, ok := properties.Get(jsonField.Name)
+		if !ok || property.Description != "" {
+			continue
+		}
+		property.Description = fields.DescriptionTag(jsonField.StructField)
+		if property.Description == "" {
+			property.Description, _ = g.Comments.Field(jsonField.Owner, jsonField.StructField)
+		}
+		properties.Set(jsonField.Name, property)
+	}
 	return properties
 }
 
-func (g DefinitionGenerator) timeProperty(field reflect.StructField, required bool) SchemaProperty {
+// quotedProperty converts the property of a field with the ',string' option, whose
+// value encoding/json writes as a JSON string, into a string property.
+func quotedProperty(property SchemaProperty) SchemaProperty {
+	property.Type = "string"
+	property.Format = ""
+	property.Ref = ""
+	property.Minimum, property.Maximum = nil, nil
+	property.ExclusiveMinimum, property.ExclusiveMaximum = false, false
+	if property.Enum != nil {
+		enum := make([]interface{}, len(property.Enum))
+		for i, value := range property.Enum {
+			enum[i] = fmt.Sprint(value)
+		}
+		property.Enum = enum
+	}
+	if property.Default != nil {
+		property.Default = fmt.Sprint(property.Default)
+	}
+	if property.Example != nil {
+		property.Example = fmt.Sprint(property.Example)
+	}
+	return property
+}
+
+// applyTags applies the validation constraints and the documentation annotations of the
+// struct tags of field to the property.
+func applyTags(property SchemaProperty, field reflect.StructField) SchemaProperty {
+	return applyAnnotations(applyConstraints(property, fields.Validation(field)), fields.Documentation(field))
+}
+
+// applyAnnotations copies the annotations of the documentation tags onto the property.
+// Like constraints, they are not applied to references, whose siblings are ignored.
+func applyAnnotations(property SchemaProperty, a fields.Annotations) SchemaProperty {
+	if property.Ref != "" {
+		return property
+	}
+	if a.Default != nil {
+		property.Default = a.Default
+	}
+	if a.Format != "" {
+		property.Format = a.Format
+	}
+	if a.Pattern != "" {
+		property.Pattern = a.Pattern
+	}
+	if a.ReadOnly {
+		property.ReadOnly = true
+	}
+	if a.Nullable {
+		property.Nullable = true
+	}
+	if a.WriteOnly {
+		property.WriteOnly = true
+	}
+	if a.Deprecated {
+		property.Deprecated = true
+	}
+	return property
+}
+
+// applyConstraints copies the validator derived constraints onto the property.
+// References are left untouched since siblings of a $ref are ignored.
+func applyConstraints(property SchemaProperty, c fields.Constraints) SchemaProperty {
+	if property.Ref != "" {
+		return property
+	}
+	if c.Minimum != nil {
+		property.Minimum = c.Minimum
+		property.ExclusiveMinimum = c.ExclusiveMinimum
+	}
+	if c.Maximum != nil {
+		property.Maximum = c.Maximum
+		property.ExclusiveMaximum = c.ExclusiveMaximum
+	}
+	if c.MinLength != nil {
+		property.MinLength = c.MinLength
+	}
+	if c.MaxLength != nil {
+		property.MaxLength = c.MaxLength
+	}
+	if c.MinItems != nil {
+		property.MinItems = c.MinItems
+	}
+	if c.MaxItems != nil {
+		property.MaxItems = c.MaxItems
+	}
+	if c.UniqueItems {
+		property.UniqueItems = true
+	}
+	if c.Pattern != "" {
+		property.Pattern = c.Pattern
+	}
+	if c.Format != "" {
+		property.Format = c.Format
+	}
+	if c.Enum != nil {
+		property.Enum = c.Enum
+	}
+	return property
+}
+
+// registeredProperty returns the property for a field whose (possibly pointer)
+// type is found in the type registry.
+func (g DefinitionGenerator) registeredProperty(field reflect.StructField) (SchemaProperty, bool) {
+	schema, ok := g.Types.Lookup(field.Type)
+	if !ok {
+		return SchemaProperty{}, false
+	}
+	required := g.isRequired(field)
+	if field.Type.Kind() == reflect.Pointer {
+		required = fields.IsRequired(field)
+	}
 	return SchemaProperty{
-		Type:        "string",
-		Format:      "date-time",
+		Type:        schema.Type,
+		Format:      schema.Format,
 		IsRequired:  required,
-		Nullable:    field.Type.Kind() == reflect.Pointer,
-		Example:     fields.ExampleTag(field),
+		Nullable:    schema.Nullable || field.Type.Kind() == reflect.Pointer,
+		Example:     g.example(field),
 		Description: fields.DescriptionTag(field),
+	}, true
+}
+
+// knownProperty returns the property for a (possibly pointer) type that is found in
+// the type registry, implements SchemaProvider, is an interface or a map, or has
+// enum values, creating the schemas it references.
+func (g DefinitionGenerator) knownProperty(t reflect.Type) (SchemaProperty, bool) {
+	if schema, ok := g.Types.Lookup(t); ok {
+		return SchemaProperty{
+			Type:     schema.Type,
+			Format:   schema.Format,
+			Nullable: schema.Nullable,
+		}, true
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
 	}
+	if _, ok := schemaProvider(t); ok {
+		g.CreateDefinition(reflect.New(t).Elem().Interface())
+		return SchemaProperty{
+			Ref: fmt.Sprintf("#/components/schemas/%s", g.name(t)),
+		}, true
+	}
+	if t.Kind() == reflect.Interface {
+		if g.createInterfaceSchema(t) {
+			return SchemaProperty{
+				Ref: fmt.Sprintf("#/components/schemas/%s", g.name(t)),
+			}, true
+		}
+		return SchemaProperty{}, true
+	}
+	if t.Kind() == reflect.Map {
+		value := g.typeProperty(t.Elem())
+		return SchemaProperty{
+			Type:                 "object",
+			AdditionalProperties: &value,
+		}, true
+	}
+	if enum, ok := g.Enums.Lookup(t); ok {
+		if g.EnumSchemas {
+			g.CreateDefinition(reflect.New(t).Elem().Interface())
+			return SchemaProperty{
+				Ref: fmt.Sprintf("#/components/schemas/%s", g.name(t)),
+			}, true
+		}
+		property := primitiveProperty(t)
+		property.Enum = enum.Values
+		return property, true
+	}
+	return SchemaProperty{}, false
 }
 
-func (g DefinitionGenerator) durationProperty(field reflect.StructField, required bool) SchemaProperty {
+// typeProperty returns the property describing values of type t, e.g. the values of
+// a map, creating the schemas of the structs it references.
+func (g DefinitionGenerator) typeProperty(t reflect.Type) SchemaProperty {
+	if property, ok := g.knownProperty(t); ok {
+		return property
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		property := SchemaProperty{
+			Type:  "array",
+			Items: asItems(g.typeProperty(t.Elem())),
+		}
+		if t.Kind() == reflect.Array {
+			// fixed-size arrays always hold exactly their length of elements
+			length := int64(t.Len())
+			property.MinItems, property.MaxItems = &length, &length
+		}
+		return property
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.inlineProperty(t)
+		}
+		name := g.name(t)
+		g.CreateDefinition(reflect.New(t).Elem().Interface())
+		return SchemaProperty{
+			Ref: fmt.Sprintf("#/components/schemas/%s", name),
+		}
+	}
+	return primitiveProperty(t)
+}
+
+// primitiveProperty returns the property describing values of the kind of t, with
+// the format and lower bound of the numeric kinds.
+func primitiveProperty(t reflect.Type) SchemaProperty {
 	return SchemaProperty{
-		Type:        "integer",
+		Type:    fields.Type(t.Kind().String()),
+		Format:  fields.Format(t.Kind()),
+		Minimum: fields.Minimum(t.Kind()),
+	}
+}
+
+// InlineProperty returns the property documenting values of type t in place, like the
+// values of struct fields: anonymous structs with their properties, named structs by
+// reference. It is used for anonymous struct bodies and responses, which have no
+// schema to reference.
+func (g DefinitionGenerator) InlineProperty(t reflect.Type) SchemaProperty {
+	return g.typeProperty(t)
+}
+
+// inlineProperty returns the object property of an anonymous struct type, documenting
+// its fields in place.
+func (g DefinitionGenerator) inlineProperty(t reflect.Type) SchemaProperty {
+	properties := g.createStructDefinitions(fields.JSONFields(t))
+	return SchemaProperty{
+		Type:       "object",
+		Properties: properties,
+		Required:   g.findRequiredFields(properties),
+	}
+}
+
+// asItems converts the property describing the elements of an array into its items.
+func asItems(property SchemaProperty) *SchemaItems {
+	return &SchemaItems{
+		Type:                 property.Type,
+		Ref:                  property.Ref,
+		Items:                property.Items,
+		Format:               property.Format,
+		Enum:                 property.Enum,
+		Minimum:              property.Minimum,
+		MinItems:             property.MinItems,
+		MaxItems:             property.MaxItems,
+		Properties:           property.Properties,
+		Required:             property.Required,
+		AdditionalProperties: property.AdditionalProperties,
+	}
+}
+
+// interfaceProperty returns a reference to the oneOf schema of a field whose
+// interface type has registered implementations. Any value may be stored in
+// other interface fields, so they are documented without a type.
+func (g DefinitionGenerator) interfaceProperty(field reflect.StructField) SchemaProperty {
+	property := SchemaProperty{
+		IsRequired:  g.isRequired(field),
+		Example:     g.example(field),
+		Description: fields.DescriptionTag(field),
+	}
+	if g.createInterfaceSchema(field.Type) {
+		property.Ref = fmt.Sprintf("#/components/schemas/%s", g.name(field.Type))
+	}
+	return property
+}
+
+// createInterfaceSchema adds the schema of an interface type with registered
+// implementations, along with the schemas of the implementations. The interface
+// schema is a oneOf of the implementations with a discriminator mapping to them.
+// It reports false when t has no registered implementations.
+func (g DefinitionGenerator) createInterfaceSchema(t reflect.Type) bool {
+	implementations, ok := g.Interfaces.Lookup(t)
+	if !ok {
+		return false
+	}
+	schemaName := g.name(t)
+	if _, ok := g.Schemas[schemaName]; ok {
+		return true // already created, or being created by a recursive implementation
+	}
+
+	values := make([]string, 0, len(implementations.Mapping))
+	for value := range implementations.Mapping {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	schema := Schema{
+		OneOf: []*Schema{},
+		Discriminator: &Discriminator{
+			PropertyName: implementations.PropertyName,
+			Mapping:      map[string]string{},
+		},
+	}
+	g.recordName(schemaName, t.String())
+	g.Schemas[schemaName] = schema
+
+	for _, value := range values {
+		implementation := implementations.Mapping[value]
+		ref := fmt.Sprintf("#/components/schemas/%s", g.name(reflect.TypeOf(implementation)))
+		g.CreateDefinition(implementation)
+		schema.Discriminator.Mapping[value] = ref
+		if !containsRef(schema.OneOf, ref) {
+			schema.OneOf = append(schema.OneOf, &Schema{Ref: ref})
+		}
+	}
+	g.Schemas[schemaName] = schema
+	return true
+}
+
+func containsRef(schemas []*Schema, ref string) bool {
+	for _, schema := range schemas {
+		if schema.Ref == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// enumProperty returns the property for a field whose (possibly pointer) type has
+// enum values. With EnumSchemas the property references the enum's schema.
+func (g DefinitionGenerator) enumProperty(field reflect.StructField) (SchemaProperty, bool) {
+	t := field.Type
+	required := g.isRequired(field)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		required = fields.IsRequired(field)
+	}
+	enum, ok := g.Enums.Lookup(t)
+	if !ok {
+		return SchemaProperty{}, false
+	}
+	property := SchemaProperty{}
+	if g.EnumSchemas {
+		g.CreateDefinition(reflect.New(t).Elem().Interface())
+		property.Ref = fmt.Sprintf("#/components/schemas/%s", g.name(t))
+	} else {
+		property = primitiveProperty(t)
+		property.Enum = enum.Values
+	}
+	property.IsRequired = required
+	property.Nullable = field.Type.Kind() == reflect.Pointer
+	property.Example = g.example(field)
+	property.Description = fields.DescriptionTag(field)
+	return property, true
+}
+
+// providedProperty returns a reference to the schema of a field whose
+// (possibly pointer) type implements SchemaProvider, creating the schema.
+func (g DefinitionGenerator) providedProperty(field reflect.StructField) (SchemaProperty, bool) {
+	t := field.Type
+	required := g.isRequired(field)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		required = fields.IsRequired(field)
+	}
+	if _, ok := schemaProvider(t); !ok {
+		return SchemaProperty{}, false
+	}
+	g.CreateDefinition(reflect.New(t).Elem().Interface())
+	return SchemaProperty{
+		Ref:         fmt.Sprintf("#/components/schemas/%s", g.name(t)),
 		IsRequired:  required,
 		Nullable:    field.Type.Kind() == reflect.Pointer,
-		Example:     fields.ExampleTag(field),
+		Example:     g.example(field),
 		Description: fields.DescriptionTag(field),
+	}, true
+}
+
+// schemaProvider returns the SchemaProvider implemented by t, or by a pointer to t.
+// Pointer types are resolved to their element type first.
+func schemaProvider(t reflect.Type) (SchemaProvider, bool) {
+	if t == nil {
+		return nil, false
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
 	}
+	provider, ok := reflect.New(t).Interface().(SchemaProvider)
+	return provider, ok
 }
 
 func (g DefinitionGenerator) refProperty(field reflect.StructField, required bool) SchemaProperty {
 	return SchemaProperty{
-		Ref:         fmt.Sprintf("#/components/schemas/%s", fields.RefName(field.Type.Elem().String(), g.HidePackageName)),
+		Ref:         fmt.Sprintf("#/components/schemas/%s", g.name(field.Type.Elem())),
 		IsRequired:  required,
 		Nullable:    true,
-		Example:     fields.ExampleTag(field),
+		Example:     g.example(field),
 		Description: fields.DescriptionTag(field),
 	}
 }
 
 func (g DefinitionGenerator) defaultProperty(field reflect.StructField) SchemaProperty {
-	return SchemaProperty{
-		Type:        fields.Type(field.Type.Kind().String()),
-		IsRequired:  g.isRequired(field),
-		Nullable:    field.Type.Kind() == reflect.Pointer,
-		Example:     fields.ExampleTag(field),
-		Description: fields.DescriptionTag(field),
-	}
+	property := primitiveProperty(field.Type)
+	property.IsRequired = g.isRequired(field)
+	property.Nullable = field.Type.Kind() == reflect.Pointer
+	property.Example = g.example(field)
+	property.Description = fields.DescriptionTag(field)
+	return property
 }
 
 func (g DefinitionGenerator) isRequired(field reflect.StructField) bool {
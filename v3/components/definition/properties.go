@@ -0,0 +1,111 @@
+package definition
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Property is a named property of a Schema.
+type Property struct {
+	Name   string
+	Schema SchemaProperty
+}
+
+// Properties holds the properties of a Schema in the declaration order of the
+// struct fields they document, with the fields of embedded structs in place of the
+// embedded struct. It is marshaled as a JSON object keeping that order.
+type Properties []Property
+
+// Get returns the property with the given name.
+func (p Properties) Get(name string) (SchemaProperty, bool) {
+	for _, property := range p {
+		if property.Name == name {
+			return property.Schema, true
+		}
+	}
+	return SchemaProperty{}, false
+}
+
+// Set replaces the property with the given name in place, or appends it if there
+// is none.
+func (p *Properties) Set(name string, schema SchemaProperty) {
+	for i, property := range *p {
+		if property.Name == name {
+			(*p)[i].Schema = schema
+			return
+		}
+	}
+	*p = append(*p, Property{Name: name, Schema: schema})
+}
+
+// Delete removes the property with the given name.
+func (p *Properties) Delete(name string) {
+	for i, property := range *p {
+		if property.Name == name {
+			*p = append((*p)[:i], (*p)[i+1:]...)
+			return
+		}
+	}
+}
+
+// Names returns the names of the properties in order.
+func (p Properties) Names() []string {
+	names := make([]string, 0, len(p))
+	for _, property := range p {
+		names = append(names, property.Name)
+	}
+	return names
+}
+
+func (p Properties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, property := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(property.Name)
+		if err != nil {
+			return nil, err
+		}
+		schema, err := json.Marshal(property.Schema)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(schema)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (p *Properties) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		*p = nil
+		return nil
+	}
+	if token != json.Delim('{') {
+		return fmt.Errorf("definition: properties must be a JSON object, got %v", token)
+	}
+	properties := Properties{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		var schema SchemaProperty
+		if err := decoder.Decode(&schema); err != nil {
+			return err
+		}
+		properties = append(properties, Property{Name: token.(string), Schema: schema})
+	}
+	*p = properties
+	return nil
+}
@@ -1,11 +1,10 @@
 package endpoint
 
 import (
-	"fmt"
-	"reflect"
+	"encoding/json"
 
 	"github.com/go-swagno/swagno/v3/components/extensions"
-	"github.com/go-swagno/swagno/v3/components/fields"
+	"github.com/go-swagno/swagno/v3/components/http"
 	"github.com/go-swagno/swagno/v3/components/http/response"
 	"github.com/go-swagno/swagno/v3/components/mime"
 	"github.com/go-swagno/swagno/v3/components/parameter"
@@ -91,6 +90,7 @@ func (l Link) MarshalJSON() ([]byte, error) {
 // RequestBody represents a request body in OpenAPI 3.0
 // https://spec.openapis.org/oas/v3.0.3#request-body-object
 type RequestBody struct {
+	Ref         string                `json:"$ref,omitempty"`
 	Description string                `json:"description,omitempty"`
 	Content     map[string]MediaType  `json:"content"`
 	Required    bool                  `json:"required,omitempty"`
@@ -98,6 +98,9 @@ type RequestBody struct {
 }
 
 func (r RequestBody) MarshalJSON() ([]byte, error) {
+	if r.Ref != "" {
+		return json.Marshal(map[string]string{"$ref": r.Ref})
+	}
 	type alias RequestBody
 	return extensions.Merge(alias(r), r.Extensions)
 }
@@ -133,6 +136,7 @@ func (j JsonEndPoint) MarshalJSON() ([]byte, error) {
 // It encapsulates the description, content, headers, and links of a response object.
 // See: https://spec.openapis.org/oas/v3.0.3#response-object
 type JsonResponse struct {
+	Ref         string                 `json:"$ref,omitempty"`
 	Description string                 `json:"description"`
 	Headers     map[string]interface{} `json:"headers,omitempty"`
 	Content     map[string]MediaType   `json:"content,omitempty"`
@@ -145,6 +149,9 @@ type JsonResponse struct {
 }
 
 func (r JsonResponse) MarshalJSON() ([]byte, error) {
+	if r.Ref != "" {
+		return json.Marshal(map[string]string{"$ref": r.Ref})
+	}
 	type alias JsonResponse
 	return extensions.Merge(alias(r), r.Extensions)
 }
@@ -174,6 +181,10 @@ type EndPoint struct {
 	callbacks         map[string]Callback
 	servers           []OperationServer
 	extensions        extensions.Extensions
+	paramRefs         []string
+	responseRefs      []string
+	bodyRef           string
+	formEncodings     map[string]*http.Encoding
 }
 
 // AsJson converts an EndPoint into its JSON representation as JsonEndPoint.
@@ -243,22 +254,42 @@ func (e *EndPoint) Path() string {
 	return e.path
 }
 
+// ParamRefs returns the names of the registered parameters the EndPoint references.
+func (e *EndPoint) ParamRefs() []string {
+	return e.paramRefs
+}
+
+// ResponseRefs returns the names of the registered responses the EndPoint references.
+func (e *EndPoint) ResponseRefs() []string {
+	return e.responseRefs
+}
+
+// FormEncodings returns the encodings of the form request body fields, by field name.
+func (e *EndPoint) FormEncodings() map[string]*http.Encoding {
+	return e.formEncodings
+}
+
+// BodyRef returns the name of the registered request body the EndPoint references, if any.
+func (e *EndPoint) BodyRef() string {
+	return e.bodyRef
+}
+
 // BodyJsonParameter creates the request body parameter for OpenAPI 3.0.
 // In OpenAPI 3.0, request bodies are handled differently than in Swagger 2.0
+// The schema is generated by a ResponseGenerator without registered types, enums or
+// interfaces, see BodyJsonParameterWith.
 func (e *EndPoint) BodyJsonParameter(hidePackageName bool) *parameter.JsonParameter {
-	if e.Body.Content != nil {
-		bodyRef := fmt.Sprintf("#/components/schemas/%s", fields.RefName(fmt.Sprintf("%T", e.Body.Content), hidePackageName))
-		bodySchema := parameter.JsonResponseSchema{
-			Ref: bodyRef,
-		}
+	return e.BodyJsonParameterWith(response.NewResponseGenerator(hidePackageName))
+}
 
-		if reflect.TypeOf(e.Body.Content).Kind() == reflect.Slice {
-			bodySchema = parameter.JsonResponseSchema{
-				Type: "array",
-				Items: &parameter.JsonResponseSchemeItems{
-					Ref: fmt.Sprintf("#/components/schemas/%s", fields.RefName(fmt.Sprintf("%T", e.Body.Content), hidePackageName)),
-				},
-			}
+// BodyJsonParameterWith creates the request body parameter like BodyJsonParameter.
+// The schema is generated by the given ResponseGenerator, so structs are referenced by their schema
+// while primitives and collections are documented inline.
+func (e *EndPoint) BodyJsonParameterWith(generator *response.ResponseGenerator) *parameter.JsonParameter {
+	if e.Body.Content != nil {
+		bodySchema := generator.Generate(e.Body.Content)
+		if bodySchema == nil {
+			bodySchema = &parameter.JsonResponseSchema{Type: "object"}
 		}
 
 		p := &parameter.JsonParameter{
@@ -266,7 +297,7 @@ func (e *EndPoint) BodyJsonParameter(hidePackageName bool) *parameter.JsonParame
 			In:          "body",
 			Description: e.Body.description,
 			Required:    true,
-			Schema:      &bodySchema,
+			Schema:      bodySchema,
 		}
 
 		if e.Body.required != nil {
@@ -324,6 +355,43 @@ func WithParams(params ...*parameter.Parameter) EndPointOption {
 	}
 }
 
+// WithParamsFrom adds a parameter for each bound field of the struct value, e.g.
+// WithParamsFrom(ListOrdersRequest{}), as described by parameter.ParamsFrom.
+func WithParamsFrom(value interface{}) EndPointOption {
+	return WithParams(parameter.ParamsFrom(value)...)
+}
+
+// WithFormParamsFrom adds a parameter for each bound field of the struct value a form
+// request body is bound to, e.g. WithFormParamsFrom(UploadAvatarRequest{}), as described
+// by parameter.FormParamsFrom. Fields with a 'form' tag are documented as the properties
+// of the form request body.
+func WithFormParamsFrom(value interface{}) EndPointOption {
+	return WithParams(parameter.FormParamsFrom(value)...)
+}
+
+// WithFormEncoding sets the encoding of the form request body field named field, such
+// as its content type, headers or style, e.g.
+//
+//	WithFormEncoding("avatar", &http.Encoding{ContentType: "image/png, image/jpeg"})
+//
+// It takes precedence over the content type of the parameter.
+func WithFormEncoding(field string, encoding *http.Encoding) EndPointOption {
+	return func(e *EndPoint) {
+		if e.formEncodings == nil {
+			e.formEncodings = make(map[string]*http.Encoding)
+		}
+		e.formEncodings[field] = encoding
+	}
+}
+
+// WithParamRefs references parameters registered with OpenAPI.RegisterParameter by name, so the
+// EndPoint documents them as '$ref: #/components/parameters/{name}' instead of repeating them.
+func WithParamRefs(names ...string) EndPointOption {
+	return func(e *EndPoint) {
+		e.paramRefs = append(e.paramRefs, names...)
+	}
+}
+
 type bodyOptions struct {
 	description string
 	required    *bool
@@ -331,21 +399,24 @@ type bodyOptions struct {
 	examples    map[string]interface{}
 }
 
+// BodyOption configures a request body, see WithBody and OpenAPI.RegisterRequestBody.
+type BodyOption = func(*bodyOptions)
+
 // WithBodyOptions allows setting additional options for the request body, such as description and whether it's required.
-func WithBodyDescription(description string) func(*bodyOptions) {
+func WithBodyDescription(description string) BodyOption {
 	return func(bo *bodyOptions) {
 		bo.description = description
 	}
 }
 
-func WithBodyRequired(required bool) func(*bodyOptions) {
+func WithBodyRequired(required bool) BodyOption {
 	return func(bo *bodyOptions) {
 		bo.required = &required
 	}
 }
 
 // WithBodyExample sets an example for the request body and clears multiple examples.
-func WithBodyExample(example interface{}) func(*bodyOptions) {
+func WithBodyExample(example interface{}) BodyOption {
 	return func(bo *bodyOptions) {
 		bo.example = example
 		bo.examples = nil
@@ -353,7 +424,7 @@ func WithBodyExample(example interface{}) func(*bodyOptions) {
 }
 
 // WithBodyExamples sets multiple examples for the request body and clears a single example.
-func WithBodyExamples(examples map[string]interface{}) func(*bodyOptions) {
+func WithBodyExamples(examples map[string]interface{}) BodyOption {
 	return func(bo *bodyOptions) {
 		bo.examples = examples
 		bo.example = nil
@@ -361,7 +432,7 @@ func WithBodyExamples(examples map[string]interface{}) func(*bodyOptions) {
 }
 
 // WithBody specifies the data structure that the EndPoint expects in the request body.
-func WithBody(body interface{}, opts ...func(*bodyOptions)) EndPointOption {
+func WithBody(body interface{}, opts ...BodyOption) EndPointOption {
 	return func(e *EndPoint) {
 		bo := &bodyOptions{}
 		for _, opt := range opts {
@@ -383,6 +454,15 @@ func WithBody(body interface{}, opts ...func(*bodyOptions)) EndPointOption {
 	}
 }
 
+// WithBodyRef references a request body registered with OpenAPI.RegisterRequestBody by
+// name, so the EndPoint documents it as '$ref: #/components/requestBodies/{name}'. It
+// takes precedence over WithBody.
+func WithBodyRef(name string) EndPointOption {
+	return func(e *EndPoint) {
+		e.bodyRef = name
+	}
+}
+
 // WithSuccessfulReturns sets the possible successful response types that the EndPoint can return.
 func WithSuccessfulReturns(ret []response.Response) EndPointOption {
 	return func(e *EndPoint) {
@@ -397,6 +477,15 @@ func WithErrors(err []response.Response) EndPointOption {
 	}
 }
 
+// WithResponseRefs references responses registered with OpenAPI.RegisterResponse by name. Each is
+// documented under the return code it was registered with as '$ref: #/components/responses/{name}',
+// replacing a successful return or error with the same code.
+func WithResponseRefs(names ...string) EndPointOption {
+	return func(e *EndPoint) {
+		e.responseRefs = append(e.responseRefs, names...)
+	}
+}
+
 // WithDescription sets a descriptive text for the EndPoint, providing context or information about its purpose.
 func WithDescription(des string) EndPointOption {
 	return func(e *EndPoint) {
@@ -8,7 +8,9 @@
 package extensions
 
 import (
+	"bytes"
 	"encoding/json"
+	"sort"
 	"strings"
 )
 
@@ -20,7 +22,8 @@ type Extensions map[string]any
 const Prefix = "x-"
 
 // Merge serializes v with the standard JSON marshaler and splices the x-*
-// entries of ext into the resulting object.
+// entries of ext into the resulting object. The fields of v keep their order,
+// the extensions follow them sorted by key.
 //
 // Callers must pass a type alias of the host struct (one without its own
 // MarshalJSON method) so this call does not recurse.
@@ -29,18 +32,33 @@ func Merge(v any, ext Extensions) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	if len(ext) == 0 {
+
+	keys := make([]string, 0, len(ext))
+	for k := range ext {
+		if strings.HasPrefix(k, Prefix) {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
 		return base, nil
 	}
+	sort.Strings(keys)
 
-	var m map[string]any
-	if err := json.Unmarshal(base, &m); err != nil {
-		return nil, err
-	}
-	for k, val := range ext {
-		if strings.HasPrefix(k, Prefix) {
-			m[k] = val
+	var buf bytes.Buffer
+	buf.Write(bytes.TrimSuffix(bytes.TrimSpace(base), []byte("}")))
+	for i, k := range keys {
+		val, err := json.Marshal(ext[k])
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 || len(bytes.TrimSpace(base)) > 2 {
+			buf.WriteByte(',')
 		}
+		name, _ := json.Marshal(k)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(val)
 	}
-	return json.Marshal(m)
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
 }
@@ -0,0 +1,26 @@
+package fields
+
+import "reflect"
+
+// Implementations describes the concrete types that may be stored in an interface
+// type and how they are told apart in their JSON representation.
+type Implementations struct {
+	// PropertyName is the property whose value identifies the implementation.
+	PropertyName string
+	// Mapping maps each value of the discriminating property to a zero value of
+	// the implementation, e.g. {"circle": Circle{}, "square": Square{}}.
+	Mapping map[string]interface{}
+}
+
+// Interfaces maps interface types to their registered implementations.
+type Interfaces map[reflect.Type]Implementations
+
+// Lookup returns the implementations registered for the interface type t.
+// It is safe to call on a nil Interfaces.
+func (interfaces Interfaces) Lookup(t reflect.Type) (Implementations, bool) {
+	if t == nil || t.Kind() != reflect.Interface {
+		return Implementations{}, false
+	}
+	implementations, ok := interfaces[t]
+	return implementations, ok && len(implementations.Mapping) > 0
+}
@@ -0,0 +1,163 @@
+package fields
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Field is a struct field as encoding/json serializes it.
+type Field struct {
+	reflect.StructField
+	// Name is the key of the field in the JSON object.
+	Name string
+	// Tagged reports whether Name comes from the json tag rather than the Go field name.
+	Tagged bool
+	// Quoted reports whether the ',string' option encodes the value as a JSON string.
+	Quoted bool
+	// OmitEmpty reports whether the ',omitempty' option omits empty values.
+	OmitEmpty bool
+	// Owner is the struct type declaring the field, which is an embedded struct for
+	// promoted fields.
+	Owner reflect.Type
+}
+
+// JSONFields returns the fields of the struct type t that encoding/json serializes, in
+// the order it serializes them. As in encoding/json, unexported fields and fields tagged
+// `json:"-"` are skipped, untagged fields are named after the Go field and the fields of
+// embedded structs are promoted in place of the embedded struct. When several fields
+// share a name the least nested one wins, then the tagged one, and fields remaining
+// ambiguous are all dropped. The Index of promoted fields is their full index sequence.
+func JSONFields(t reflect.Type) []Field {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	all := collectJSONFields(t, nil, map[reflect.Type]bool{t: true})
+
+	byName := map[string][]Field{}
+	for _, field := range all {
+		byName[field.Name] = append(byName[field.Name], field)
+	}
+	result := []Field{}
+	for _, field := range all {
+		if dominant, ok := dominantField(byName[field.Name]); ok && sameIndex(dominant.Index, field.Index) {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+// collectJSONFields returns the candidate fields of t in declaration order, descending
+// into embedded structs. Types in path are being collected already, which stops
+// embedding cycles through pointers.
+func collectJSONFields(t reflect.Type, index []int, path map[reflect.Type]bool) []Field {
+	result := []Field{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if !sf.IsExported() && ft.Kind() != reflect.Struct {
+				continue // embedded fields of unexported non-struct types are ignored
+			}
+		} else if !sf.IsExported() {
+			continue
+		}
+
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		sf.Index = append(append([]int{}, index...), i)
+
+		ft := sf.Type
+		if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+			if !path[ft] {
+				path[ft] = true
+				result = append(result, collectJSONFields(ft, sf.Index, path)...)
+				delete(path, ft)
+			}
+			continue
+		}
+
+		field := Field{
+			StructField: sf,
+			Name:        name,
+			Tagged:      name != "",
+			OmitEmpty:   hasOption(options, "omitempty"),
+			Owner:       t,
+		}
+		if field.Name == "" {
+			field.Name = sf.Name
+		}
+		if hasOption(options, "string") {
+			switch ft.Kind() {
+			case reflect.Bool, reflect.String,
+				reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+				reflect.Float32, reflect.Float64:
+				field.Quoted = true
+			}
+		}
+		result = append(result, field)
+	}
+	return result
+}
+
+// dominantField returns the field that encoding/json serializes among fields sharing a
+// name: the least nested one, preferring a tagged one among equally nested fields.
+// It reports false when that leaves more than one field.
+func dominantField(fields []Field) (Field, bool) {
+	depth := len(fields[0].Index)
+	for _, field := range fields {
+		if len(field.Index) < depth {
+			depth = len(field.Index)
+		}
+	}
+	var dominant []Field
+	tagged := false
+	for _, field := range fields {
+		if len(field.Index) != depth {
+			continue
+		}
+		if field.Tagged && !tagged {
+			dominant, tagged = nil, true
+		}
+		if field.Tagged == tagged {
+			dominant = append(dominant, field)
+		}
+	}
+	if len(dominant) != 1 {
+		return Field{}, false
+	}
+	return dominant[0], true
+}
+
+func sameIndex(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func hasOption(options, option string) bool {
+	for _, o := range strings.Split(options, ",") {
+		if strings.TrimSpace(o) == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package fields
+
+import (
+	"reflect"
+	"strings"
+)
+
+// NameStrategy names the component schemas of Go types. The same name is used as the
+// definition key and in the references to it, so both always agree.
+// Names should only use the characters A-Z a-z 0-9 . - _ which are valid in references.
+type NameStrategy interface {
+	Name(t reflect.Type) string
+}
+
+// NameFunc is a NameStrategy implemented by a function, e.g. a user callback.
+type NameFunc func(t reflect.Type) string
+
+// Name calls f(t).
+func (f NameFunc) Name(t reflect.Type) string {
+	return f(t)
+}
+
+// genericStrategy is a built-in NameStrategy, which names the instantiations of
+// generic types with the GenericNameFunc it is given, see GenericNames.
+type genericStrategy func(t reflect.Type, generic GenericNameFunc) string
+
+// Name names t with the instantiations of generic types named by joinTypeArgs.
+func (f genericStrategy) Name(t reflect.Type) string {
+	return f(t, joinTypeArgs)
+}
+
+// QualifiedNames names types by their package name and type name, e.g. "models.Product".
+// It is the default NameStrategy.
+func QualifiedNames() NameStrategy {
+	return genericStrategy(func(t reflect.Type, generic GenericNameFunc) string {
+		return refName(t.String(), false, generic)
+	})
+}
+
+// UnqualifiedNames names types by their type name only, e.g. "Product". It is the
+// NameStrategy used with the HidePackageName option.
+func UnqualifiedNames() NameStrategy {
+	return genericStrategy(func(t reflect.Type, generic GenericNameFunc) string {
+		return refName(t.String(), true, generic)
+	})
+}
+
+// ImportPathNames names types by their full import path with slashes replaced by
+// dots, e.g. "github.com.acme.api.models.Product".
+func ImportPathNames() NameStrategy {
+	return PathSegmentNames(0)
+}
+
+// PathSegmentNames names types by the last n segments of their import path joined
+// by dots, e.g. "api.models.Product" for n = 2. The full import path is used when
+// n is not positive or exceeds the number of segments.
+func PathSegmentNames(n int) NameStrategy {
+	return genericStrategy(func(t reflect.Type, generic GenericNameFunc) string {
+		if t.PkgPath() == "" || t.Name() == "" {
+			return refName(t.String(), false, generic)
+		}
+		segments := strings.Split(t.PkgPath(), "/")
+		if n > 0 && n < len(segments) {
+			segments = segments[len(segments)-n:]
+		}
+		return strings.Join(segments, ".") + "." + genericName(t.Name(), generic)
+	})
+}
+
+// PackagePrefixNames names the types of the packages in prefixes, keyed by import
+// path, by their prefix followed by their type name, e.g. "BillingInvoice" for
+// {"github.com/acme/billing/models": "Billing"}. Types of other packages are named
+// as with QualifiedNames.
+func PackagePrefixNames(prefixes map[string]string) NameStrategy {
+	return genericStrategy(func(t reflect.Type, generic GenericNameFunc) string {
+		if prefix, ok := prefixes[t.PkgPath()]; ok && t.Name() != "" {
+			return prefix + genericName(t.Name(), generic)
+		}
+		return refName(t.String(), false, generic)
+	})
+}
+
+// DefinitionName returns the name of the schema of t according to names. A nil
+// NameStrategy defaults to QualifiedNames, or UnqualifiedNames when hidePackage is true.
+func DefinitionName(t reflect.Type, names NameStrategy, hidePackage bool) string {
+	if t == nil {
+		return ""
+	}
+	if names == nil {
+		return RefName(t.String(), hidePackage)
+	}
+	return names.Name(t)
+}
@@ -1,27 +1,39 @@
 package fields
 
 import (
+	"fmt"
 	"reflect"
-	"strconv"
 	"strings"
 )
 
-// ExampleTag retrieves the 'example' struct tag's value and converts it to an integer if possible.
-// If conversion is successful, it returns the uint64 value, else it returns the raw string.
+// ExampleTag retrieves the 'example' struct tag's value converted to the field's type,
+// as done by Example. Values that cannot be converted are returned as the raw string.
 func ExampleTag(field reflect.StructField) interface{} {
-	tagValue := field.Tag.Get("example")
-
-	if tagValue != "" {
-		numValue, err := strconv.ParseFloat(tagValue, 64) // for json float covers for all numbers
-		if err == nil {
-			return numValue
-		}
-		return tagValue
+	value, err := Example(field, nil)
+	if err != nil {
+		return field.Tag.Get("example")
 	}
+	return value
+}
 
-	return nil
+// Example converts the 'example' struct tag's value to the type of the field: strings
+// are used as-is, booleans and numbers are parsed and slices, maps and structs are
+// decoded from JSON. Types registered in types, or mapped by default such as time.Time,
+// are converted according to their schema type. It returns nil when the field has no
+// example, and an error when the example is not a valid value of the field's type.
+func Example(field reflect.StructField, types Types) (interface{}, error) {
+	tagValue := field.Tag.Get("example")
+	if tagValue == "" {
+		return nil, nil
+	}
+	value, err := convertValue(field.Type, tagValue, types)
+	if err != nil {
+		return nil, fmt.Errorf("example %q of field %s is not a valid %s: %w", tagValue, field.Name, field.Type, err)
+	}
+	return value, nil
 }
 
+// DescriptionTag retrieves the 'desc' struct tag's value, which describes the property of the field.
 func DescriptionTag(field reflect.StructField) string {
 	return field.Tag.Get("desc")
 }
@@ -29,11 +41,25 @@ func DescriptionTag(field reflect.StructField) string {
 // JsonTag extracts the 'json' struct tag's value of a struct field and returns it as a string.
 // If the tag contains options (comma-separated), only the name part before the comma is returned.
 func JsonTag(field reflect.StructField) string {
-	jsonTag := field.Tag.Get("json")
-	if strings.Index(jsonTag, ",") > 0 {
-		return strings.Split(jsonTag, ",")[0]
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name
+}
+
+// EmbeddedStruct returns the struct type of an embedded struct, or pointer to a struct,
+// whose fields encoding/json promotes into the parent struct. Embedded structs with an
+// explicit json name are regular fields and are not reported.
+func EmbeddedStruct(field reflect.StructField) (reflect.Type, bool) {
+	if !field.Anonymous {
+		return nil, false
 	}
-	return jsonTag
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" {
+		return nil, false
+	}
+	t := field.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct
 }
 
 // IsOmitempty extracts the 'json' struct tag's value of a struct field and returns if it has omitempty.
@@ -48,16 +74,25 @@ func IsOmitempty(field reflect.StructField) bool {
 }
 
 // IsRequired extracts the 'required' struct tag's value of a struct field and returns true if required is true.
+// A 'required' rule in the field's 'validate' or 'binding' tag also marks the field as required.
 func IsRequired(field reflect.StructField) bool {
 	tagValue := field.Tag.Get("required")
-	return tagValue == "true"
+	return tagValue == "true" || Validation(field).Required
 }
 
 // RefName returns the type name with its leading package qualifier removed when
 // hidePackage is true (e.g. "models.MyStruct" -> "MyStruct"). It is a no-op when
 // hidePackage is false. Synthetic names such as "models.Product.metadata" become
 // "Product.metadata", since only the leading package segment is stripped.
+// Instantiations of generic types are named by joining their type arguments first,
+// e.g. "models.Page_Product".
 func RefName(name string, hidePackage bool) string {
+	return refName(name, hidePackage, joinTypeArgs)
+}
+
+// refName returns RefName with the instantiations of generic types named by generic.
+func refName(name string, hidePackage bool, generic GenericNameFunc) string {
+	name = genericName(name, generic)
 	if !hidePackage {
 		return name
 	}
@@ -83,3 +118,54 @@ func Type(t string) string {
 	}
 	return t
 }
+
+// Format returns the format telling the sizes of the integer and floating point kinds
+// apart: "int32" for integers fitting in 32 bits, "int64" for the other integers,
+// "float" for float32 and "double" for float64. Other kinds have no format.
+func Format(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return "int32"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "int64"
+	case reflect.Float32:
+		return "float"
+	case reflect.Float64:
+		return "double"
+	}
+	return ""
+}
+
+// Minimum returns the lower bound of the unsigned integer kinds, which is 0, or nil
+// for other kinds.
+func Minimum(kind reflect.Kind) *float64 {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		min := 0.0
+		return &min
+	}
+	return nil
+}
+
+// AnonymousStruct reports whether t is an anonymous struct type, or a pointer to, or a
+// collection of, anonymous structs.
+func AnonymousStruct(t reflect.Type) bool {
+	for {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			return t.Name() == ""
+		default:
+			return false
+		}
+	}
+}
+
+// Deref returns the type t points to, through any number of pointers, or t itself.
+func Deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,74 @@
+package fields
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net"
+	"net/url"
+	"reflect"
+	"time"
+)
+
+// TypeSchema describes how values of a Go type are documented when the type is
+// registered in Types, replacing the schema reflection would produce.
+// An empty TypeSchema documents the type as an unconstrained value.
+type TypeSchema struct {
+	Type     string
+	Format   string
+	Nullable bool
+}
+
+// Types maps Go types to the schema they are documented with. It is consulted
+// before reflection, which makes it possible to document third-party and named
+// types whose Go representation differs from their JSON representation.
+type Types map[reflect.Type]TypeSchema
+
+// builtinTypes are the mappings applied to well-known standard library types
+// unless they are overridden by a user registration.
+var builtinTypes = Types{
+	reflect.TypeOf(time.Time{}):       {Type: "string", Format: "date-time"},
+	reflect.TypeOf(time.Duration(0)):  {Type: "integer", Format: "int64"},
+	reflect.TypeOf([]byte{}):          {Type: "string", Format: "byte"},
+	reflect.TypeOf(json.RawMessage{}): {},
+	reflect.TypeOf(net.IP{}):          {Type: "string", Format: "ip"},
+	reflect.TypeOf(url.URL{}):         {Type: "string", Format: "uri"},
+	reflect.TypeOf(sql.NullString{}):  {Type: "string", Nullable: true},
+	reflect.TypeOf(sql.NullBool{}):    {Type: "boolean", Nullable: true},
+	reflect.TypeOf(sql.NullByte{}):    {Type: "integer", Format: "int32", Nullable: true},
+	reflect.TypeOf(sql.NullInt16{}):   {Type: "integer", Format: "int32", Nullable: true},
+	reflect.TypeOf(sql.NullInt32{}):   {Type: "integer", Format: "int32", Nullable: true},
+	reflect.TypeOf(sql.NullInt64{}):   {Type: "integer", Format: "int64", Nullable: true},
+	reflect.TypeOf(sql.NullFloat64{}): {Type: "number", Format: "double", Nullable: true},
+	reflect.TypeOf(sql.NullTime{}):    {Type: "string", Format: "date-time", Nullable: true},
+}
+
+// Lookup returns the schema registered for t, falling back to the built-in
+// mappings. Pointer types are resolved to their element type.
+// It is safe to call on a nil Types.
+func (types Types) Lookup(t reflect.Type) (TypeSchema, bool) {
+	if t == nil {
+		return TypeSchema{}, false
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if schema, ok := types[t]; ok {
+		return schema, true
+	}
+	schema, ok := builtinTypes[t]
+	return schema, ok
+}
+
+// ProvidesSchema reports whether t, or a pointer to t, has a SwaggerSchema method
+// and therefore describes its own schema through the definition package's
+// SchemaProvider interface. Such types are always referenced by name.
+func ProvidesSchema(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	_, ok := reflect.PointerTo(t).MethodByName("SwaggerSchema")
+	return ok
+}
@@ -21,19 +21,16 @@ type OpenAPI3Parameter struct {
 func (p *Parameter) AsOpenAPI3Json() OpenAPI3Parameter {
 	// Create schema object - validation fields are within the schema
 	schema := &JsonResponseSchema{
-		Type:        p.typeValue.String(),
-		UniqueItems: p.uniqueItems,
+		Type:         p.typeValue.String(),
+		Items:        p.arrayItems(),
+		Min:          p.min,
+		Max:          p.max,
+		ExclusiveMin: p.exclusiveMin,
+		ExclusiveMax: p.exclusiveMax,
+		UniqueItems:  p.uniqueItems,
+		MultipleOf:   p.multipleOf,
 	}
 
-	// Add min/max values
-	if p.min != 0 {
-		min := float64(p.min)
-		schema.Min = &min
-	}
-	if p.max != 0 {
-		max := float64(p.max)
-		schema.Max = &max
-	}
 	if p.minLen != 0 {
 		schema.MinLen = &p.minLen
 	}
@@ -46,10 +43,6 @@ func (p *Parameter) AsOpenAPI3Json() OpenAPI3Parameter {
 	if p.maxItems != 0 {
 		schema.MaxItems = &p.maxItems
 	}
-	if p.multipleOf != 0 {
-		multiple := float64(p.multipleOf)
-		schema.MultipleOf = &multiple
-	}
 
 	openAPI3Param := OpenAPI3Parameter{
 		Name:        p.name,
@@ -61,13 +54,7 @@ func (p *Parameter) AsOpenAPI3Json() OpenAPI3Parameter {
 	}
 
 	// OpenAPI 3.0 specific features
-	if p.style != "" {
-		openAPI3Param.Style = p.style
-	}
-	if p.explode {
-		explode := true
-		openAPI3Param.Explode = &explode
-	}
+	openAPI3Param.Style, openAPI3Param.Explode = p.serialization()
 	if p.allowReserved {
 		openAPI3Param.AllowReserved = p.allowReserved
 	}
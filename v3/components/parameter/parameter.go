@@ -1,10 +1,13 @@
 package parameter
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/go-swagno/swagno/v3/components/extensions"
+	"github.com/go-swagno/swagno/v3/components/fields"
 )
 
 // ComponentExample represents an example object for parameters
@@ -38,6 +41,27 @@ func (c CollectionFormat) String() string {
 	return string(c)
 }
 
+// Style returns the OpenAPI 3.0 style and explode values serializing array parameters
+// at location in like the Swagger 2.0 collection format c. Formats without an
+// equivalent, such as tsv, return an empty style and a nil explode.
+func (c CollectionFormat) Style(in Location) (string, *bool) {
+	exploded, joined := true, false
+	switch c {
+	case CSV:
+		if in == Query || in == Cookie {
+			return "form", &joined
+		}
+		return "simple", &joined
+	case SSV:
+		return "spaceDelimited", &joined
+	case Pipes:
+		return "pipeDelimited", &joined
+	case Multi:
+		return "form", &exploded
+	}
+	return "", nil
+}
+
 // Location specifies where in the request a parameter is expected to be located.
 type Location string
 
@@ -51,9 +75,8 @@ const (
 	Header Location = "header"
 	Path   Location = "path"
 	Cookie Location = "cookie" // New in OpenAPI 3.0
-	// Note: In OpenAPI 3.0, form data is handled through requestBody.content,
-	// not as individual parameters. The 'Form' location is kept for backwards compatibility
-	// but should be handled differently in the request body processing.
+	// Form parameters are documented as the properties of the form request body, since
+	// OpenAPI 3.0 handles form data through requestBody.content rather than parameters.
 	Form Location = "formData"
 )
 
@@ -78,6 +101,7 @@ const (
 // JsonParameter is the JSON model version of Parameter object used for API purposes
 // https://spec.openapis.org/oas/v3.0.3#parameter-object
 type JsonParameter struct {
+	Ref             string                      `json:"$ref,omitempty"`
 	Name            string                      `json:"name"`
 	In              string                      `json:"in"`
 	Description     string                      `json:"description,omitempty"`
@@ -95,6 +119,9 @@ type JsonParameter struct {
 }
 
 func (p JsonParameter) MarshalJSON() ([]byte, error) {
+	if p.Ref != "" {
+		return json.Marshal(map[string]string{"$ref": p.Ref})
+	}
 	type alias JsonParameter
 	return extensions.Merge(alias(p), p.Extensions)
 }
@@ -103,42 +130,53 @@ func (p JsonParameter) MarshalJSON() ([]byte, error) {
 // It is used to describe the structure and type of a response returned by an API endpoint.
 // https://spec.openapis.org/oas/v3.0.3#schema-object
 type JsonResponseSchema struct {
-	Ref           string                         `json:"$ref,omitempty"`
-	Type          string                         `json:"type,omitempty"`
-	Format        string                         `json:"format,omitempty"`
-	Items         *JsonResponseSchemeItems       `json:"items,omitempty"`
-	Properties    map[string]*JsonResponseSchema `json:"properties,omitempty"`
-	Required      []string                       `json:"required,omitempty"`
-	AllOf         []*JsonResponseSchema          `json:"allOf,omitempty"`
-	OneOf         []*JsonResponseSchema          `json:"oneOf,omitempty"`
-	AnyOf         []*JsonResponseSchema          `json:"anyOf,omitempty"`
-	Not           *JsonResponseSchema            `json:"not,omitempty"`
-	Title         string                         `json:"title,omitempty"`
-	Description   string                         `json:"description,omitempty"`
-	Default       interface{}                    `json:"default,omitempty"`
-	Example       interface{}                    `json:"example,omitempty"`
-	Enum          []interface{}                  `json:"enum,omitempty"`
-	Min           *float64                       `json:"minimum,omitempty"`
-	Max           *float64                       `json:"maximum,omitempty"`
-	MinLen        *int64                         `json:"minLength,omitempty"`
-	MaxLen        *int64                         `json:"maxLength,omitempty"`
-	Pattern       string                         `json:"pattern,omitempty"`
-	MinItems      *int64                         `json:"minItems,omitempty"`
-	MaxItems      *int64                         `json:"maxItems,omitempty"`
-	UniqueItems   bool                           `json:"uniqueItems,omitempty"`
-	MultipleOf    *float64                       `json:"multipleOf,omitempty"`
-	Nullable      bool                           `json:"nullable,omitempty"`
-	ReadOnly      bool                           `json:"readOnly,omitempty"`
-	WriteOnly     bool                           `json:"writeOnly,omitempty"`
-	Deprecated    bool                           `json:"deprecated,omitempty"`
-	Discriminator *Discriminator                 `json:"discriminator,omitempty"`
-	XML           *XML                           `json:"xml,omitempty"`
-	ExternalDocs  *ExternalDocs                  `json:"externalDocs,omitempty"`
+	Ref                  string                         `json:"$ref,omitempty"`
+	Type                 string                         `json:"type,omitempty"`
+	Format               string                         `json:"format,omitempty"`
+	Items                *JsonResponseSchemeItems       `json:"items,omitempty"`
+	Properties           map[string]*JsonResponseSchema `json:"properties,omitempty"`
+	AdditionalProperties *JsonResponseSchema            `json:"additionalProperties,omitempty"`
+	Required             []string                       `json:"required,omitempty"`
+	AllOf                []*JsonResponseSchema          `json:"allOf,omitempty"`
+	OneOf                []*JsonResponseSchema          `json:"oneOf,omitempty"`
+	AnyOf                []*JsonResponseSchema          `json:"anyOf,omitempty"`
+	Not                  *JsonResponseSchema            `json:"not,omitempty"`
+	Title                string                         `json:"title,omitempty"`
+	Description          string                         `json:"description,omitempty"`
+	Default              interface{}                    `json:"default,omitempty"`
+	Example              interface{}                    `json:"example,omitempty"`
+	Enum                 []interface{}                  `json:"enum,omitempty"`
+	Min                  *float64                       `json:"minimum,omitempty"`
+	Max                  *float64                       `json:"maximum,omitempty"`
+	ExclusiveMin         bool                           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMax         bool                           `json:"exclusiveMaximum,omitempty"`
+	MinLen               *int64                         `json:"minLength,omitempty"`
+	MaxLen               *int64                         `json:"maxLength,omitempty"`
+	Pattern              string                         `json:"pattern,omitempty"`
+	MinItems             *int64                         `json:"minItems,omitempty"`
+	MaxItems             *int64                         `json:"maxItems,omitempty"`
+	UniqueItems          bool                           `json:"uniqueItems,omitempty"`
+	MultipleOf           *float64                       `json:"multipleOf,omitempty"`
+	Nullable             bool                           `json:"nullable,omitempty"`
+	ReadOnly             bool                           `json:"readOnly,omitempty"`
+	WriteOnly            bool                           `json:"writeOnly,omitempty"`
+	Deprecated           bool                           `json:"deprecated,omitempty"`
+	Discriminator        *Discriminator                 `json:"discriminator,omitempty"`
+	XML                  *XML                           `json:"xml,omitempty"`
+	ExternalDocs         *ExternalDocs                  `json:"externalDocs,omitempty"`
 
 	Extensions extensions.Extensions `json:"-"`
+
+	// Inline, when set, is documented in place of the schema, e.g. the schema property
+	// of an anonymous struct, which keeps the order of its properties.
+	Inline interface{} `json:"-"`
 }
 
+// MarshalJSON documents schemas with an Inline value as that value.
 func (s JsonResponseSchema) MarshalJSON() ([]byte, error) {
+	if s.Inline != nil {
+		return json.Marshal(s.Inline)
+	}
 	type alias JsonResponseSchema
 	return extensions.Merge(alias(s), s.Extensions)
 }
@@ -185,9 +223,15 @@ func (ed ExternalDocs) MarshalJSON() ([]byte, error) {
 // JsonResponseSchemeItems represents the individual items in a JsonResponseSchema, especially for arrays.
 // It provides the type or reference for the array items.
 type JsonResponseSchemeItems struct {
-	Type  string                   `json:"type,omitempty"`
-	Ref   string                   `json:"$ref,omitempty"`
-	Items *JsonResponseSchemeItems `json:"items,omitempty"`
+	Type                 string                   `json:"type,omitempty"`
+	Format               string                   `json:"format,omitempty"`
+	Ref                  string                   `json:"$ref,omitempty"`
+	Items                *JsonResponseSchemeItems `json:"items,omitempty"`
+	Enum                 []interface{}            `json:"enum,omitempty"`
+	Min                  *float64                 `json:"minimum,omitempty"`
+	MinItems             *int64                   `json:"minItems,omitempty"`
+	MaxItems             *int64                   `json:"maxItems,omitempty"`
+	AdditionalProperties *JsonResponseSchema      `json:"additionalProperties,omitempty"`
 }
 
 // Parameter represents a parameter in an API endpoint.
@@ -200,15 +244,18 @@ type Parameter struct {
 	enum             []interface{}
 	defaultValue     interface{}
 	format           string
-	min              int64
-	max              int64
+	items            *JsonResponseSchemeItems
+	min              *float64
+	max              *float64
+	exclusiveMin     bool
+	exclusiveMax     bool
 	minLen           int64
 	maxLen           int64
 	pattern          string
 	maxItems         int64
 	minItems         int64
 	uniqueItems      bool
-	multipleOf       int64
+	multipleOf       *float64
 	collectionFormat CollectionFormat
 	deprecated       bool
 	allowEmptyValue  bool
@@ -217,6 +264,9 @@ type Parameter struct {
 	allowReserved    bool
 	example          interface{}
 	examples         map[string]interface{}
+	enumType         reflect.Type
+	fieldType        reflect.Type
+	contentType      string
 }
 
 // Location returns the location of the parameter (i.e. Query, Body, Path, and etc.)
@@ -224,26 +274,43 @@ func (p Parameter) Location() Location {
 	return p.in
 }
 
+// EnumType returns the named type the allowed values of an EnumParam, or of the items
+// of an array parameter created by ParamsFrom, are resolved from, or nil for other
+// parameters.
+func (p Parameter) EnumType() reflect.Type {
+	return p.enumType
+}
+
+// ContentType returns the content type a Form parameter is encoded with in multipart
+// request bodies, or "" for the default of its type.
+func (p Parameter) ContentType() string {
+	return p.contentType
+}
+
+// FieldType returns the type of the struct field a parameter created by ParamsFrom or
+// FormParamsFrom is bound to, or nil for other parameters.
+func (p Parameter) FieldType() reflect.Type {
+	return p.fieldType
+}
+
 // AsJson returns the json representation of Parameter for OpenAPI 3.0
 func (p *Parameter) AsJson() JsonParameter {
 	// Create schema object for OpenAPI 3.0
 	schema := &JsonResponseSchema{
-		Type:        p.typeValue.String(),
-		Format:      p.format,
-		Enum:        p.enum,
-		Default:     p.defaultValue,
-		Pattern:     p.pattern,
-		UniqueItems: p.uniqueItems,
+		Type:         p.typeValue.String(),
+		Format:       p.format,
+		Items:        p.arrayItems(),
+		Enum:         p.enum,
+		Default:      p.defaultValue,
+		Min:          p.min,
+		Max:          p.max,
+		ExclusiveMin: p.exclusiveMin,
+		ExclusiveMax: p.exclusiveMax,
+		Pattern:      p.pattern,
+		UniqueItems:  p.uniqueItems,
+		MultipleOf:   p.multipleOf,
 	}
 
-	if p.min != 0 {
-		min := float64(p.min)
-		schema.Min = &min
-	}
-	if p.max != 0 {
-		max := float64(p.max)
-		schema.Max = &max
-	}
 	if p.minLen != 0 {
 		schema.MinLen = &p.minLen
 	}
@@ -256,10 +323,6 @@ func (p *Parameter) AsJson() JsonParameter {
 	if p.maxItems != 0 {
 		schema.MaxItems = &p.maxItems
 	}
-	if p.multipleOf != 0 {
-		multiple := float64(p.multipleOf)
-		schema.MultipleOf = &multiple
-	}
 	if p.example != nil {
 		schema.Example = p.example
 	}
@@ -275,13 +338,7 @@ func (p *Parameter) AsJson() JsonParameter {
 	}
 
 	// Set style and explode for query and path parameters
-	if p.style != "" {
-		jsonParam.Style = p.style
-	}
-	if p.explode {
-		explode := true
-		jsonParam.Explode = &explode
-	}
+	jsonParam.Style, jsonParam.Explode = p.serialization()
 	if p.allowReserved {
 		jsonParam.AllowReserved = p.allowReserved
 	}
@@ -318,6 +375,12 @@ func IntParam(name string, l Location, opts ...Option) *Parameter {
 	return newParam(name, opts...)
 }
 
+// NumberParam creates a number parameter, for floating-point values.
+func NumberParam(name string, l Location, opts ...Option) *Parameter {
+	opts = append(opts, WithType(Number), WithIn(l))
+	return newParam(name, opts...)
+}
+
 // StrParam creates a string parameter.
 func StrParam(name string, l Location, opts ...Option) *Parameter {
 	opts = append(opts, WithType(String), WithIn(l))
@@ -330,7 +393,8 @@ func BoolParam(name string, l Location, opts ...Option) *Parameter {
 	return newParam(name, opts...)
 }
 
-// FileParam creates a file parameter - Note: In OpenAPI 3.0, file uploads are handled differently
+// FileParam creates a file parameter. It is documented as a binary string property of the
+// form request body, since OpenAPI 3.0 has no formData parameters.
 func FileParam(name string, opts ...Option) *Parameter {
 	opts = append(opts, WithType("string"), WithFormat("binary"), WithIn(Form))
 	return newParam(name, opts...)
@@ -352,9 +416,9 @@ func IntEnumParam(name string, l Location, arr []int64, opts ...Option) *Paramet
 	return param
 }
 
-// StrEnumParam creates a string enum parameter.
-func StrEnumParam(name string, l Location, arr []string, opts ...Option) *Parameter {
-	opts = append(opts, WithType(String), WithIn(l))
+// FloatEnumParam creates a number enum parameter.
+func FloatEnumParam(name string, l Location, arr []float64, opts ...Option) *Parameter {
+	opts = append(opts, WithType(Number), WithIn(l))
 	param := newParam(name, opts...)
 
 	if len(arr) > 0 {
@@ -368,9 +432,9 @@ func StrEnumParam(name string, l Location, arr []string, opts ...Option) *Parame
 	return param
 }
 
-// IntArrParam creates an integer array parameter.
-func IntArrParam(name string, l Location, arr []int64, opts ...Option) *Parameter {
-	opts = append(opts, WithType(Array), WithIn(l))
+// StrEnumParam creates a string enum parameter.
+func StrEnumParam(name string, l Location, arr []string, opts ...Option) *Parameter {
+	opts = append(opts, WithType(String), WithIn(l))
 	param := newParam(name, opts...)
 
 	if len(arr) > 0 {
@@ -384,20 +448,64 @@ func IntArrParam(name string, l Location, arr []int64, opts ...Option) *Paramete
 	return param
 }
 
-// StrArrParam creates a string array parameter.
-func StrArrParam(name string, l Location, arr []string, opts ...Option) *Parameter {
-	opts = append(opts, WithType(Array), WithIn(Location(l)))
+// EnumParam creates a parameter for a named type with a fixed set of values,
+// e.g. EnumParam("status", Query, models.OrderStatus("")). The parameter type follows
+// the kind of value, numeric kinds also set the format unless WithFormat is given and
+// unsigned kinds have a minimum of 0 unless WithMinimum is given. The allowed values come from its Enum method, or from the enums registered on the
+// config when the documentation is generated.
+func EnumParam(name string, l Location, value interface{}, opts ...Option) *Parameter {
+	t := reflect.TypeOf(value)
+	opts = append([]Option{WithFormat(fields.Format(t.Kind()))}, opts...)
+	if min := fields.Minimum(t.Kind()); min != nil {
+		opts = append([]Option{WithMinimum(*min)}, opts...)
+	}
+	opts = append(opts, WithType(ParamType(fields.Type(t.Kind().String()))), WithIn(l))
 	param := newParam(name, opts...)
+	param.enumType = t
+
+	if enum, ok := fields.Enums(nil).Lookup(t); ok {
+		param.enum = enum.Values
+	}
 
+	return param
+}
+
+// IntArrParam creates an integer array parameter. The values of arr, if any, are the
+// allowed values of its items.
+func IntArrParam(name string, l Location, arr []int64, opts ...Option) *Parameter {
+	items := &JsonResponseSchemeItems{Type: Integer.String()}
 	if len(arr) > 0 {
-		s := make([]interface{}, len(arr))
+		items.Enum = make([]interface{}, len(arr))
 		for i, v := range arr {
-			s[i] = v
+			items.Enum[i] = v
 		}
-		param.enum = s
 	}
+	opts = append([]Option{WithItems(items)}, opts...)
+	opts = append(opts, WithType(Array), WithIn(l))
+	return newParam(name, opts...)
+}
 
-	return param
+// StrArrParam creates a string array parameter. The values of arr, if any, are the
+// allowed values of its items.
+func StrArrParam(name string, l Location, arr []string, opts ...Option) *Parameter {
+	items := &JsonResponseSchemeItems{Type: String.String()}
+	if len(arr) > 0 {
+		items.Enum = make([]interface{}, len(arr))
+		for i, v := range arr {
+			items.Enum[i] = v
+		}
+	}
+	opts = append([]Option{WithItems(items)}, opts...)
+	opts = append(opts, WithType(Array), WithIn(l))
+	return newParam(name, opts...)
+}
+
+// ArrParam creates an array parameter whose items are described by items, e.g. for
+// arrays of numbers, enums or, with nested items, arrays of arrays.
+func ArrParam(name string, l Location, items *JsonResponseSchemeItems, opts ...Option) *Parameter {
+	opts = append([]Option{WithItems(items)}, opts...)
+	opts = append(opts, WithType(Array), WithIn(l))
+	return newParam(name, opts...)
 }
 
 // Option represents a function that can modify a Parameter.
@@ -431,6 +539,33 @@ func WithDescription(description string) Option {
 	}
 }
 
+// WithEnum sets the Enum field of a Parameter.
+func WithEnum(values ...interface{}) Option {
+	return func(p *Parameter) {
+		p.enum = values
+	}
+}
+
+// WithItemsEnum sets the allowed values of the items of an array parameter, or of the
+// innermost items of nested arrays. The items are copied, so items shared with other
+// parameters are left unchanged.
+func WithItemsEnum(values ...interface{}) Option {
+	return func(p *Parameter) {
+		p.items = itemsWithEnum(p.arrayItems(), values)
+	}
+}
+
+// itemsWithEnum returns a copy of items whose innermost items have the allowed values.
+func itemsWithEnum(items *JsonResponseSchemeItems, values []interface{}) *JsonResponseSchemeItems {
+	withEnum := *items
+	if withEnum.Items != nil {
+		withEnum.Items = itemsWithEnum(withEnum.Items, values)
+	} else {
+		withEnum.Enum = values
+	}
+	return &withEnum
+}
+
 // WithDefault sets the Default field of a Parameter.
 func WithDefault(defaultValue interface{}) Option {
 	return func(p *Parameter) {
@@ -445,20 +580,60 @@ func WithFormat(format string) Option {
 	}
 }
 
-// WithMin sets the Min field of a Parameter.
+// WithMin sets the Min field of a Parameter. A zero min leaves the minimum as it is,
+// use WithMinimum for a minimum of 0.
 func WithMin(min int64) Option {
 	return func(p *Parameter) {
-		p.min = min
+		if min != 0 {
+			p.min, p.exclusiveMin = intBound(min), false
+		}
 	}
 }
 
-// WithMax sets the Max field of a Parameter.
+// WithMax sets the Max field of a Parameter. A zero max leaves the maximum as it is,
+// use WithMaximum for a maximum of 0.
 func WithMax(max int64) Option {
 	return func(p *Parameter) {
-		p.max = max
+		if max != 0 {
+			p.max, p.exclusiveMax = intBound(max), false
+		}
+	}
+}
+
+// WithMinimum sets the inclusive minimum of a Parameter, which may be fractional or 0.
+func WithMinimum(min float64) Option {
+	return func(p *Parameter) {
+		p.min, p.exclusiveMin = &min, false
+	}
+}
+
+// WithMaximum sets the inclusive maximum of a Parameter, which may be fractional or 0.
+func WithMaximum(max float64) Option {
+	return func(p *Parameter) {
+		p.max, p.exclusiveMax = &max, false
+	}
+}
+
+// WithExclusiveMinimum sets a minimum of a Parameter that values must be greater than.
+func WithExclusiveMinimum(min float64) Option {
+	return func(p *Parameter) {
+		p.min, p.exclusiveMin = &min, true
 	}
 }
 
+// WithExclusiveMaximum sets a maximum of a Parameter that values must be less than.
+func WithExclusiveMaximum(max float64) Option {
+	return func(p *Parameter) {
+		p.max, p.exclusiveMax = &max, true
+	}
+}
+
+// intBound returns the bound set by an integer option.
+func intBound(bound int64) *float64 {
+	value := float64(bound)
+	return &value
+}
+
 // WithMinLen sets the MinLen field of a Parameter.
 func WithMinLen(minLen int64) Option {
 	return func(p *Parameter) {
@@ -501,14 +676,33 @@ func WithUniqueItems(uniqueItems bool) Option {
 	}
 }
 
-// WithMultipleOf sets the MultipleOf field of a Parameter.
+// WithMultipleOf sets the MultipleOf field of a Parameter. A zero multipleOf leaves
+// it as it is.
 func WithMultipleOf(multipleOf int64) Option {
 	return func(p *Parameter) {
-		p.multipleOf = multipleOf
+		if multipleOf != 0 {
+			p.multipleOf = intBound(multipleOf)
+		}
+	}
+}
+
+// WithMultipleOfNumber sets the MultipleOf field of a Parameter to a number, which
+// may be fractional, e.g. 0.01 for amounts in cents.
+func WithMultipleOfNumber(multipleOf float64) Option {
+	return func(p *Parameter) {
+		p.multipleOf = &multipleOf
+	}
+}
+
+// WithItems sets the items of an array Parameter.
+func WithItems(items *JsonResponseSchemeItems) Option {
+	return func(p *Parameter) {
+		p.items = items
 	}
 }
 
-// WithCollectionFormat sets the CollectionFormat field of a Parameter.
+// WithCollectionFormat sets the CollectionFormat field of a Parameter, which documents
+// the style and explode of array parameters as described by CollectionFormat.Style.
 func WithCollectionFormat(c CollectionFormat) Option {
 	return func(p *Parameter) {
 		p.collectionFormat = c
@@ -566,6 +760,57 @@ func WithExamples(examples map[string]interface{}) Option {
 	}
 }
 
+// WithTypes documents a parameter created by ParamsFrom or FormParamsFrom whose field
+// type, or the type of its items, is registered in types with the registered schema,
+// e.g. a uuid.UUID field as a string instead of an array of bytes.
+func WithTypes(types fields.Types) Option {
+	return func(p *Parameter) {
+		if p.fieldType == nil {
+			return
+		}
+		if schema, ok := types.Lookup(p.fieldType); ok {
+			if p.typeValue == Array {
+				p.items, p.collectionFormat = nil, ""
+			}
+			p.typeValue, p.format = ParamType(schema.Type), schema.Format
+			return
+		}
+		if p.typeValue == Array {
+			p.items = paramItems(p.fieldType.Elem(), types)
+		}
+	}
+}
+
+// WithContentType sets the content type a Form parameter is encoded with in multipart
+// request bodies, e.g. WithContentType("image/png, image/jpeg") for a file.
+func WithContentType(contentType string) Option {
+	return func(p *Parameter) {
+		p.contentType = contentType
+	}
+}
+
+// arrayItems returns the items of the parameter. Array parameters without items get
+// string items, since array schemas require them.
+func (p *Parameter) arrayItems() *JsonResponseSchemeItems {
+	if p.typeValue == Array && p.items == nil {
+		return &JsonResponseSchemeItems{Type: String.String()}
+	}
+	return p.items
+}
+
+// serialization returns the style and explode of the parameter. Unless WithStyle or
+// WithExplode is given, they follow the collection format set by WithCollectionFormat.
+func (p *Parameter) serialization() (string, *bool) {
+	if p.style == "" && !p.explode {
+		return p.collectionFormat.Style(p.in)
+	}
+	if p.explode {
+		explode := true
+		return p.style, &explode
+	}
+	return p.style, nil
+}
+
 // newParam creates a newParam parameter with the given options.
 func newParam(name string, opts ...Option) *Parameter {
 	parameter := Parameter{name: name}
@@ -581,11 +826,11 @@ func newParam(name string, opts ...Option) *Parameter {
 // generateParamDescription generates the description for a parameter based on its properties.
 func generateParamDescription(param *Parameter) {
 	newDescription := ""
-	if param.min != 0 {
-		newDescription += "min: " + fmt.Sprint(param.min) + " "
+	if param.min != nil {
+		newDescription += boundLabel("min", param.exclusiveMin) + fmt.Sprint(*param.min) + " "
 	}
-	if param.max != 0 {
-		newDescription += "max: " + fmt.Sprint(param.max) + " "
+	if param.max != nil {
+		newDescription += boundLabel("max", param.exclusiveMax) + fmt.Sprint(*param.max) + " "
 	}
 	if param.minLen != 0 {
 		newDescription += "minLength: " + fmt.Sprint(param.minLen) + " "
@@ -603,3 +848,11 @@ func generateParamDescription(param *Parameter) {
 		param.description += " (" + strings.Trim(newDescription, " ") + ")"
 	}
 }
+
+// boundLabel returns the label of a minimum or maximum in the generated description.
+func boundLabel(bound string, exclusive bool) string {
+	if exclusive {
+		return "exclusive " + bound + ": "
+	}
+	return bound + ": "
+}
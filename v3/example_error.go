@@ -0,0 +1,49 @@
+package swagno3
+
+import (
+	"sort"
+	"strings"
+)
+
+// ExampleError is returned by ToJson (and panicked by MustToJson) with StrictExamples
+// when 'example' struct tags cannot be converted to the type of their field, such as a
+// non-numeric example of an integer field or malformed JSON for a slice, map or struct
+// field. The document would otherwise show examples that do not match their schema.
+type ExampleError struct {
+	// Errors holds the conversion errors, sorted by message.
+	Errors []error
+}
+
+func (e *ExampleError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return "swagno: invalid examples: " + strings.Join(messages, "; ")
+}
+
+// sortedExampleErrors returns the recorded example conversion errors sorted by message,
+// or nil when there are none.
+func sortedExampleErrors(exampleErrors map[string]error) []error {
+	if len(exampleErrors) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(exampleErrors))
+	for message := range exampleErrors {
+		messages = append(messages, message)
+	}
+	sort.Strings(messages)
+
+	errs := make([]error, 0, len(messages))
+	for _, message := range messages {
+		errs = append(errs, exampleErrors[message])
+	}
+	return errs
+}
+
+// ExampleErrors returns the errors converting 'example' struct tags to the type of
+// their field found by the last ToJson, sorted by message. These examples are
+// documented as written, unless StrictExamples makes ToJson fail instead.
+func (o *OpenAPI) ExampleErrors() []error {
+	return o.exampleErrors
+}
@@ -137,33 +137,35 @@ func TestExamplesCombined(t *testing.T) {
 				Schemas: map[string]definition.Schema{
 					"swagno3.TestRequest": {
 						Type: "object",
-						Properties: map[string]definition.SchemaProperty{
-							"email": {
-								Type:    "string",
-								Example: "john@example.com",
-							},
-							"name": {
+						Properties: definition.Properties{
+							{Name: "name", Schema: definition.SchemaProperty{
 								Type:    "string",
 								Example: "John Doe",
-							},
+							}},
+							{Name: "email", Schema: definition.SchemaProperty{
+								Type:    "string",
+								Example: "john@example.com",
+							}},
 						},
 						Required: []string{"name", "email"},
 					},
 					"swagno3.TestResponse": {
 						Type: "object",
-						Properties: map[string]definition.SchemaProperty{
-							"email": {
-								Type:    "string",
-								Example: "test@example.com",
-							},
-							"id": {
+						Properties: definition.Properties{
+							{Name: "id", Schema: definition.SchemaProperty{
 								Type:    "integer",
-								Example: float64(12345),
-							},
-							"name": {
+								Format:  "int64",
+								Minimum: new(float64),
+								Example: uint64(12345),
+							}},
+							{Name: "name", Schema: definition.SchemaProperty{
 								Type:    "string",
 								Example: "Test Item",
-							},
+							}},
+							{Name: "email", Schema: definition.SchemaProperty{
+								Type:    "string",
+								Example: "test@example.com",
+							}},
 						},
 						Required: []string{"id", "name", "email"},
 					},
@@ -332,33 +334,35 @@ func TestExamplesCombined(t *testing.T) {
 				Schemas: map[string]definition.Schema{
 					"swagno3.TestRequest": {
 						Type: "object",
-						Properties: map[string]definition.SchemaProperty{
-							"email": {
-								Type:    "string",
-								Example: "john@example.com",
-							},
-							"name": {
+						Properties: definition.Properties{
+							{Name: "name", Schema: definition.SchemaProperty{
 								Type:    "string",
 								Example: "John Doe",
-							},
+							}},
+							{Name: "email", Schema: definition.SchemaProperty{
+								Type:    "string",
+								Example: "john@example.com",
+							}},
 						},
 						Required: []string{"email", "name"},
 					},
 					"swagno3.TestResponse": {
 						Type: "object",
-						Properties: map[string]definition.SchemaProperty{
-							"email": {
-								Type:    "string",
-								Example: "test@example.com",
-							},
-							"id": {
+						Properties: definition.Properties{
+							{Name: "id", Schema: definition.SchemaProperty{
 								Type:    "integer",
-								Example: float64(12345),
-							},
-							"name": {
+								Format:  "int64",
+								Minimum: new(float64),
+								Example: uint64(12345),
+							}},
+							{Name: "name", Schema: definition.SchemaProperty{
 								Type:    "string",
 								Example: "Test Item",
-							},
+							}},
+							{Name: "email", Schema: definition.SchemaProperty{
+								Type:    "string",
+								Example: "test@example.com",
+							}},
 						},
 						Required: []string{"id", "name", "email"},
 					},
@@ -79,9 +79,9 @@ func TestExtensionsCombined(t *testing.T) {
 				Schemas: map[string]definition.Schema{
 					"swagno3.TestExtPayload": {
 						Type: "object",
-						Properties: map[string]definition.SchemaProperty{
-							"id":   {Type: "integer", Example: float64(1)},
-							"name": {Type: "string", Example: "Alice"},
+						Properties: definition.Properties{
+							{Name: "id", Schema: definition.SchemaProperty{Type: "integer", Format: "int64", Minimum: new(float64), Example: uint64(1)}},
+							{Name: "name", Schema: definition.SchemaProperty{Type: "string", Example: "Alice"}},
 						},
 						Required: []string{"id", "name"},
 					},
@@ -231,9 +231,9 @@ func TestExtensionsCombined(t *testing.T) {
 				Schemas: map[string]definition.Schema{
 					"swagno3.TestExtPayload": {
 						Type: "object",
-						Properties: map[string]definition.SchemaProperty{
-							"id":   {Type: "integer", Example: float64(1)},
-							"name": {Type: "string", Example: "Alice"},
+						Properties: definition.Properties{
+							{Name: "id", Schema: definition.SchemaProperty{Type: "integer", Format: "int64", Minimum: new(float64), Example: uint64(1)}},
+							{Name: "name", Schema: definition.SchemaProperty{Type: "string", Example: "Alice"}},
 						},
 						Required:   []string{"id", "name"},
 						Extensions: extensions.Extensions{"x-internal-id": "payload-v1"},
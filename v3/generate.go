@@ -2,29 +2,37 @@ package swagno3
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
+	"reflect"
 	"strings"
 
 	"github.com/go-swagno/swagno/v3/components/definition"
 	"github.com/go-swagno/swagno/v3/components/endpoint"
+	"github.com/go-swagno/swagno/v3/components/http"
 	"github.com/go-swagno/swagno/v3/components/http/response"
 	"github.com/go-swagno/swagno/v3/components/mime"
 	"github.com/go-swagno/swagno/v3/components/parameter"
 )
 
-func appendResponses(sourceResponses map[string]endpoint.JsonResponse, additionalResponses []response.Response, hidePackageName bool) map[string]endpoint.JsonResponse {
-	responseGenerator := response.NewResponseGenerator(hidePackageName)
-
+func appendResponses(sourceResponses map[string]endpoint.JsonResponse, additionalResponses []response.Response, responseGenerator *response.ResponseGenerator) map[string]endpoint.JsonResponse {
 	for _, resp := range additionalResponses {
 		var responseSchema *parameter.JsonResponseSchema
 		var example interface{}
 		var examples map[string]interface{}
+		var headers map[string]interface{}
 
 		switch respType := resp.(type) {
 		case response.CustomResponse:
 			responseSchema = responseGenerator.Generate(respType.Model)
 			example = respType.Example()
 			examples = respType.Examples()
+			for _, name := range respType.HeaderRefs() {
+				if headers == nil {
+					headers = make(map[string]interface{})
+				}
+				headers[name] = map[string]string{"$ref": componentRef("headers", name)}
+			}
 		case response.Response:
 			responseSchema = responseGenerator.Generate(respType)
 		}
@@ -64,6 +72,7 @@ func appendResponses(sourceResponses map[string]endpoint.JsonResponse, additiona
 
 		sourceResponses[resp.ReturnCode()] = endpoint.JsonResponse{
 			Description: resp.Description(),
+			Headers:     headers,
 			Content:     content,
 		}
 	}
@@ -82,10 +91,28 @@ func (o *OpenAPI) generateOpenAPIJson() error {
 		return err
 	}
 
+	// add the registered components to the document, they are referenced by name from endpoints
+	// https://spec.openapis.org/oas/v3.0.3#reference-object
+	componentGenerator := response.NewResponseGenerator(o.hidePackageName)
+	componentGenerator.Types = o.types
+	componentGenerator.NameStrategy = o.nameStrategy
+	componentGenerator.Enums = o.enums
+	componentGenerator.EnumSchemas = o.enumSchemas
+	componentGenerator.Interfaces = o.interfaces
+	componentGenerator.InlineSchema = o.inlineSchema
+	refErrors := o.generateComponents(componentGenerator)
+
 	// convert all user EndPoint models to 'paths' fields of OpenAPI json
 	// https://spec.openapis.org/oas/v3.0.3#paths-object
+	var pathErrors, duplicateErrors []error
+	operations := map[string]string{}
 	for _, e := range o.endpoints {
-		path := e.Path()
+		path := endpoint.PathTemplate(e.Path())
+		if previous, ok := operations[string(e.Method())+" "+path]; ok {
+			duplicateErrors = append(duplicateErrors, fmt.Errorf("%s %s: already documented by %s %s", e.Method(), e.Path(), e.Method(), previous))
+			continue
+		}
+		operations[string(e.Method())+" "+path] = e.Path()
 
 		// Initialize PathItem if it doesn't exist
 		pathItem, exists := o.Paths[path]
@@ -95,22 +122,48 @@ func (o *OpenAPI) generateOpenAPIJson() error {
 
 		method := strings.ToLower(string(e.Method()))
 
-		for _, param := range e.Params() {
-			if param.Location() == parameter.Form {
-				endpoint.WithConsume([]mime.MIME{mime.MULTIFORM})(e)
-				break
-			}
+		refParams, errs := o.refParams(e, path)
+		refErrors = append(refErrors, errs...)
+
+		params := e.Params()
+		checked := append(append([]*parameter.Parameter{}, params...), refParams...)
+		if o.autoPathParams {
+			undeclared := undeclaredPathParams(path, checked)
+			params = append(params, undeclared...)
+			checked = append(checked, undeclared...)
 		}
+		pathErrors = append(pathErrors, pathParamErrors(e.Method(), path, checked)...)
 
+		// form parameters are the fields of the request body in OpenAPI 3.0, not parameters
 		parameters := make([]parameter.JsonParameter, 0)
-		for _, param := range e.Params() {
-			parameters = append(parameters, param.AsJson())
+		var formParams []*parameter.Parameter
+		for _, param := range params {
+			if param.Location() == parameter.Form {
+				formParams = append(formParams, param)
+				continue
+			}
+			parameters = append(parameters, o.paramJson(param))
+		}
+		for _, name := range e.ParamRefs() {
+			if _, ok := o.parameters[name]; ok {
+				parameters = append(parameters, parameter.JsonParameter{Ref: componentRef("parameters", name)})
+			}
 		}
 
 		// Creates the schema definition for all successful return and error objects, and then links them in the responses section
+		responseGenerator := response.NewResponseGenerator(o.hidePackageName)
+		responseGenerator.Types = o.types
+		responseGenerator.NameStrategy = o.nameStrategy
+		responseGenerator.Enums = o.enums
+		responseGenerator.EnumSchemas = o.enumSchemas
+		responseGenerator.Interfaces = o.interfaces
+		responseGenerator.InlineSchema = o.inlineSchema
 		responses := map[string]endpoint.JsonResponse{}
-		responses = appendResponses(responses, e.SuccessfulReturns(), o.hidePackageName)
-		responses = appendResponses(responses, e.Errors(), o.hidePackageName)
+		responses = appendResponses(responses, e.SuccessfulReturns(), responseGenerator)
+		responses = appendResponses(responses, e.Errors(), responseGenerator)
+		refErrors = append(refErrors, o.appendResponseRefs(responses, e, path)...)
+		refErrors = append(refErrors, o.headerRefErrors(fmt.Sprintf("%s %s", e.Method(), path), e.SuccessfulReturns()...)...)
+		refErrors = append(refErrors, o.headerRefErrors(fmt.Sprintf("%s %s", e.Method(), path), e.Errors()...)...)
 
 		// add each endpoint to paths field of OpenAPI
 		je := e.AsJson()
@@ -119,6 +172,9 @@ func (o *OpenAPI) generateOpenAPIJson() error {
 		je.Responses = responses
 
 		for _, res := range je.Responses {
+			if res.Ref != "" {
+				continue
+			}
 			content := res.Content[string(mime.JSON)]
 			for _, contentType := range je.Produce {
 				if contentType != mime.JSON {
@@ -128,57 +184,20 @@ func (o *OpenAPI) generateOpenAPIJson() error {
 		}
 
 		// Handle request body for OpenAPI 3.0
-		if bjp := e.BodyJsonParameter(o.hidePackageName); bjp != nil {
-			// Support multiple content types for request body
-			content := map[string]endpoint.MediaType{}
-
-			for _, m := range je.Consume {
-				mediaType := endpoint.MediaType{
-					Schema: bjp.Schema,
-				}
-
-				// Add example if available
-				if bjp.Example != nil {
-					mediaType.Example = bjp.Example
-				}
-
-				// Add examples if available
-				if bjp.Examples != nil {
-					mediaType.Examples = bjp.Examples
-				}
-
-				content[string(m)] = mediaType
+		if name := e.BodyRef(); name != "" {
+			if _, ok := o.requestBodies[name]; ok {
+				je.RequestBody = &endpoint.RequestBody{Ref: componentRef("requestBodies", name)}
+			} else {
+				refErrors = append(refErrors, fmt.Errorf("%s %s: request body %q is not registered", e.Method(), path, name))
 			}
-
-			// Add form-data support if needed
-			for _, param := range e.Params() {
-				if param.Location() == parameter.Form {
-					mediaType := endpoint.MediaType{
-						Schema: bjp.Schema,
-						// TODO: Add encoding configuration for form fields
-					}
-
-					// Add example if available
-					if bjp.Example != nil {
-						mediaType.Example = bjp.Example
-					}
-
-					// Add examples if available
-					if bjp.Examples != nil {
-						mediaType.Examples = bjp.Examples
-					}
-
-					content["multipart/form-data"] = mediaType
-					break
-				}
+		} else {
+			if bjp := e.BodyJsonParameterWith(responseGenerator); bjp != nil {
+				requestBody := newRequestBody(bjp, je.Consume)
+				je.RequestBody = &requestBody
 			}
-
-			requestBody := endpoint.RequestBody{
-				Description: "Request body",
-				Required:    bjp.Required,
-				Content:     content,
+			if len(formParams) > 0 {
+				je.RequestBody = o.addFormBody(je.RequestBody, formParams, e.FormEncodings(), je.Consume)
 			}
-			je.RequestBody = &requestBody
 		}
 
 		// Add operation to PathItem using helper method
@@ -189,9 +208,113 @@ func (o *OpenAPI) generateOpenAPIJson() error {
 		o.Paths[path] = pathItem
 	}
 
+	if len(refErrors) > 0 {
+		return &ComponentRefError{Errors: refErrors}
+	}
+	if len(pathErrors) > 0 {
+		return &PathParamError{Errors: pathErrors}
+	}
+	if len(duplicateErrors) > 0 {
+		return &DuplicateOperationError{Errors: duplicateErrors}
+	}
 	return nil
 }
 
+// addFormBody documents the form parameters as the fields of an object schema in the
+// form content types of requestBody, creating it when the endpoint has no other body.
+// The form content types are those of consume, or multipart/form-data when it has none.
+func (o *OpenAPI) addFormBody(requestBody *endpoint.RequestBody, params []*parameter.Parameter, encodings map[string]*http.Encoding, consume []mime.MIME) *endpoint.RequestBody {
+	schema := &parameter.JsonResponseSchema{
+		Type:       "object",
+		Properties: map[string]*parameter.JsonResponseSchema{},
+	}
+	var encoding map[string]interface{}
+	for _, param := range params {
+		pj := o.paramJson(param)
+		// the schema of the parameter may be shared, so the property is documented on a copy
+		property := &parameter.JsonResponseSchema{}
+		if pj.Schema != nil {
+			copied := *pj.Schema
+			property = &copied
+		}
+		property.Description = pj.Description
+		property.Deprecated = pj.Deprecated
+		if property.Type == parameter.File.String() {
+			property.Type = parameter.String.String()
+			property.Format = "binary"
+		}
+		schema.Properties[pj.Name] = property
+		if pj.Required {
+			schema.Required = append(schema.Required, pj.Name)
+		}
+
+		fieldEncoding, ok := encodings[pj.Name]
+		if !ok && param.ContentType() != "" {
+			fieldEncoding = &http.Encoding{ContentType: param.ContentType()}
+		}
+		if fieldEncoding != nil {
+			if encoding == nil {
+				encoding = make(map[string]interface{})
+			}
+			encoding[pj.Name] = fieldEncoding
+		}
+	}
+
+	var formTypes []mime.MIME
+	for _, m := range consume {
+		if m == mime.MULTIFORM || m == mime.URLFORM {
+			formTypes = append(formTypes, m)
+		}
+	}
+	if len(formTypes) == 0 {
+		formTypes = []mime.MIME{mime.MULTIFORM}
+	}
+
+	if requestBody == nil {
+		requestBody = &endpoint.RequestBody{Description: "Request body", Content: map[string]endpoint.MediaType{}}
+	}
+	for _, m := range formTypes {
+		requestBody.Content[string(m)] = endpoint.MediaType{Schema: schema, Encoding: encoding}
+	}
+	requestBody.Required = requestBody.Required || len(schema.Required) > 0
+	return requestBody
+}
+
+// newRequestBody returns the request body documenting the body parameter bjp in each of
+// the consumed content types.
+func newRequestBody(bjp *parameter.JsonParameter, consume []mime.MIME) endpoint.RequestBody {
+	// Support multiple content types for request body
+	content := map[string]endpoint.MediaType{}
+
+	for _, m := range consume {
+		mediaType := endpoint.MediaType{
+			Schema: bjp.Schema,
+		}
+
+		// Add example if available
+		if bjp.Example != nil {
+			mediaType.Example = bjp.Example
+		}
+
+		// Add examples if available
+		if bjp.Examples != nil {
+			mediaType.Examples = bjp.Examples
+		}
+
+		content[string(m)] = mediaType
+	}
+
+	requestBody := endpoint.RequestBody{
+		Description: "Request body",
+		Required:    bjp.Required,
+		Content:     content,
+	}
+	if bjp.Description != "" {
+		requestBody.Description = bjp.Description
+	}
+	return requestBody
+}
+
 // ToJson converts the OpenAPI object into its JSON representation formatted as bytes.
 // It returns a slice of bytes containing the OpenAPI documentation in JSON format.
 func (o *OpenAPI) ToJson() (jsonDocs []byte, err error) {
@@ -202,7 +325,9 @@ func (o *OpenAPI) ToJson() (jsonDocs []byte, err error) {
 }
 
 // MustToJson same thing as ToJson except for it doesn't return an error.
-// It panics if a name collision is detected while generating the document.
+// It panics if a name collision, an invalid example with StrictExamples, a reference to an
+// unregistered component, a path parameter mismatch or a duplicate operation is detected while
+// generating the document.
 func (o OpenAPI) MustToJson() (jsonDocs []byte) {
 	if err := o.generateOpenAPIJson(); err != nil {
 		panic(err)
@@ -217,28 +342,49 @@ func (o OpenAPI) MustToJson() (jsonDocs []byte) {
 }
 
 // generate "schemas" keys from endpoints: https://spec.openapis.org/oas/v3.0.3#schema-object
-// It returns a *NameCollisionError when HidePackageName causes two distinct types
-// to map to the same stripped schema name.
+// It returns a *NameCollisionError when HidePackageName or the naming of generic types
+// causes two distinct types to map to the same schema name.
+// With StrictExamples, it returns an *ExampleError when example tags cannot be converted
+// to their field type. Otherwise they are logged and reported by ExampleErrors.
 func (o *OpenAPI) generateOpenAPIDefinition() error {
-	// shared across all createDefinition calls so collisions are detected document-wide
+	// shared across all createDefinition calls so collisions and invalid examples are detected document-wide
 	definitionTypeNames := map[string]map[string]struct{}{}
+	exampleErrors := map[string]error{}
 	for _, endpoint := range o.endpoints {
 		if endpoint.Body.Content != nil {
-			o.createDefinition(endpoint.Body.Content, definitionTypeNames)
+			o.createDefinition(endpoint.Body.Content, definitionTypeNames, exampleErrors)
 		}
-		o.createDefinitions(endpoint.SuccessfulReturns(), definitionTypeNames)
-		o.createDefinitions(endpoint.Errors(), definitionTypeNames)
+		o.createDefinitions(endpoint.SuccessfulReturns(), definitionTypeNames, exampleErrors)
+		o.createDefinitions(endpoint.Errors(), definitionTypeNames, exampleErrors)
+	}
+	for _, name := range o.responseNames() {
+		o.createDefinition(o.responses[name], definitionTypeNames, exampleErrors)
+	}
+	for _, name := range o.requestBodyNames() {
+		o.createDefinition(o.requestBodies[name].Body.Content, definitionTypeNames, exampleErrors)
+	}
+	if err := collisionError(definitionTypeNames); err != nil {
+		return err
+	}
+	o.exampleErrors = sortedExampleErrors(exampleErrors)
+	if len(o.exampleErrors) == 0 {
+		return nil
+	}
+	err := &ExampleError{Errors: o.exampleErrors}
+	if o.strictExamples {
+		return err
 	}
-	return collisionError(definitionTypeNames)
+	log.Println(err)
+	return nil
 }
 
-func (o *OpenAPI) createDefinitions(r []response.Response, definitionTypeNames map[string]map[string]struct{}) {
+func (o *OpenAPI) createDefinitions(r []response.Response, definitionTypeNames map[string]map[string]struct{}, exampleErrors map[string]error) {
 	for _, obj := range r {
-		o.createDefinition(obj, definitionTypeNames)
+		o.createDefinition(obj, definitionTypeNames, exampleErrors)
 	}
 }
 
-func (o *OpenAPI) createDefinition(t interface{}, definitionTypeNames map[string]map[string]struct{}) {
+func (o *OpenAPI) createDefinition(t interface{}, definitionTypeNames map[string]map[string]struct{}, exampleErrors map[string]error) {
 	if o.Components == nil {
 		o.Components = &Components{
 			Schemas: make(map[string]definition.Schema),
@@ -248,10 +394,54 @@ func (o *OpenAPI) createDefinition(t interface{}, definitionTypeNames map[string
 		o.Components.Schemas = make(map[string]definition.Schema)
 	}
 
-	generator := definition.NewDefinitionGenerator(o.Components.Schemas, o.hidePackageName, definitionTypeNames)
-	generator.CreateDefinition(t)
+	o.definitionGenerator(o.Components.Schemas, definitionTypeNames, exampleErrors).CreateDefinition(t)
+}
+
+// definitionGenerator returns a definition generator adding the schemas of types to schemas,
+// configured like the document.
+func (o *OpenAPI) definitionGenerator(schemas map[string]definition.Schema, definitionTypeNames map[string]map[string]struct{}, exampleErrors map[string]error) *definition.DefinitionGenerator {
+	generator := definition.NewDefinitionGenerator(schemas, o.hidePackageName, definitionTypeNames)
+	generator.Types = o.types
+	generator.Enums = o.enums
+	generator.EnumSchemas = o.enumSchemas
+	generator.Interfaces = o.interfaces
+	generator.NameStrategy = o.nameStrategy
+	generator.ExampleErrors = exampleErrors
+	generator.Comments = o.comments
+	generator.EmbeddedAllOf = o.embeddedAllOf
+	return generator
+}
+
+// inlineSchema documents anonymous structs, and collections of them, in place with the
+// definition generator, so anonymous struct bodies and responses are documented like
+// anonymous struct properties. The schemas they reference are created with the document's,
+// those created here are discarded.
+func (o *OpenAPI) inlineSchema(t reflect.Type) *parameter.JsonResponseSchema {
+	generator := o.definitionGenerator(map[string]definition.Schema{}, map[string]map[string]struct{}{}, nil)
+	property := generator.InlineProperty(t)
+	return &parameter.JsonResponseSchema{Type: property.Type, Inline: property}
 }
 
 func (s *OpenAPI) sanitizeOperationID(operationID string) string {
 	return strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(operationID, "/", "_"), "{", ""), "}", "")
 }
+
+// paramJson returns the json representation of param with the types registered on the
+// config applied to its field type, and the allowed values of its enum type registered
+// on the config, which are those of the items of array parameters. param itself is left
+// unchanged, since it may be shared by several endpoints or documents.
+func (o *OpenAPI) paramJson(param *parameter.Parameter) parameter.JsonParameter {
+	resolved := *param
+	parameter.WithTypes(o.types)(&resolved)
+	pj := resolved.AsJson()
+	enum, ok := o.enums.Lookup(param.EnumType())
+	if !ok {
+		return pj
+	}
+	if pj.Schema != nil && pj.Schema.Type == parameter.Array.String() {
+		parameter.WithItemsEnum(enum.Values...)(&resolved)
+	} else {
+		parameter.WithEnum(enum.Values...)(&resolved)
+	}
+	return resolved.AsJson()
+}
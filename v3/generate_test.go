@@ -1,12 +1,22 @@
 package swagno3
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-swagno/swagno/v3/components/definition"
 	"github.com/go-swagno/swagno/v3/components/endpoint"
+	"github.com/go-swagno/swagno/v3/components/extensions"
+	"github.com/go-swagno/swagno/v3/components/fields"
+	"github.com/go-swagno/swagno/v3/components/http"
 	"github.com/go-swagno/swagno/v3/components/http/response"
 	"github.com/go-swagno/swagno/v3/components/mime"
 	"github.com/go-swagno/swagno/v3/components/parameter"
@@ -116,9 +126,10 @@ func TestSwaggerGeneration(t *testing.T) {
 				got,
 				cmpopts.EquateEmpty(),
 				cmpopts.SortSlices(func(a, b string) bool { return a < b }),
-				cmpopts.IgnoreFields(OpenAPI{}, "endpoints", "hidePackageName"),
+				cmpopts.IgnoreFields(OpenAPI{}, "endpoints", "hidePackageName", "types", "enums", "enumSchemas", "interfaces", "embeddedAllOf", "nameStrategy", "comments", "autoPathParams", "strictExamples", "exampleErrors", "parameters", "parameterErrors", "responses", "requestBodies"),
 				cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired"),
 				cmpopts.IgnoreFields(endpoint.JsonEndPoint{}, "Consume", "Produce"),
+				equateExamples(),
 			); diff != "" {
 				t.Errorf("OpenAPIJson() mismatch (-expected +got):\n%s", diff)
 			}
@@ -210,3 +221,1988 @@ func TestBodyRequired(t *testing.T) {
 		})
 	}
 }
+
+type validatedModel struct {
+	Name   string    `json:"name" validate:"required,min=1,max=64"`
+	Email  string    `json:"email,omitempty" validate:"omitempty,email"`
+	Price  float64   `json:"price" binding:"gt=0,lte=1000"`
+	Role   string    `json:"role" validate:"oneof=admin 'power user' guest"`
+	Tags   []string  `json:"tags" validate:"max=5,dive,alpha"`
+	Nick   *string   `json:"nick" validate:"required,uuid4"`
+	Active bool      `json:"active" validate:"eq=true"`
+	Since  time.Time `json:"since" validate:"min=1"`
+}
+
+func TestValidateTags(t *testing.T) {
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	openapi.AddEndpoint(endpoint.New(endpoint.POST, "/validated", endpoint.WithBody(validatedModel{})))
+	if err := openapi.generateOpenAPIDefinition(); err != nil {
+		t.Fatal(err)
+	}
+
+	schema, ok := openapi.Components.Schemas["swagno3.validatedModel"]
+	if !ok {
+		t.Fatalf("expected schema swagno3.validatedModel, got %v", openapi.Components.Schemas)
+	}
+	i64 := func(v int64) *int64 { return &v }
+	f64 := func(v float64) *float64 { return &v }
+	want := map[string]definition.SchemaProperty{
+		"name":   {Type: "string", MinLength: i64(1), MaxLength: i64(64)},
+		"email":  {Type: "string", Format: "email"},
+		"price":  {Type: "number", Format: "double", Minimum: f64(0), ExclusiveMinimum: true, Maximum: f64(1000)},
+		"role":   {Type: "string", Enum: []interface{}{"admin", "power user", "guest"}},
+		"tags":   {Type: "array", Items: &definition.SchemaItems{Type: "string"}, MaxItems: i64(5)},
+		"nick":   {Type: "string", Format: "uuid", Nullable: true},
+		"active": {Type: "boolean", Enum: []interface{}{true}},
+		"since":  {Type: "string", Format: "date-time"},
+	}
+	if diff := cmp.Diff(want, propertiesMap(schema.Properties), cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"name", "price", "role", "tags", "nick", "active", "since"}, schema.Required); diff != "" {
+		t.Errorf("required mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// uuidLike mimics a third-party UUID type whose Go representation ([16]byte)
+// differs from its JSON representation (a string).
+type uuidLike [16]byte
+
+type registeredTypesModel struct {
+	ID       uuidLike        `json:"id"`
+	ParentID *uuidLike       `json:"parent_id"`
+	Related  []uuidLike      `json:"related"`
+	Avatar   []byte          `json:"avatar"`
+	Raw      json.RawMessage `json:"raw"`
+	Addr     net.IP          `json:"addr"`
+	Nick     sql.NullString  `json:"nick"`
+	Created  time.Time       `json:"created"`
+}
+
+func TestRegisterType(t *testing.T) {
+	cfg := Config{Title: "Testing API", Version: "v1.0.0"}
+	cfg.RegisterType(reflect.TypeOf(uuidLike{}), fields.TypeSchema{Type: "string", Format: "uuid"})
+	openapi := New(cfg)
+	openapi.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/registered",
+		endpoint.WithSuccessfulReturns([]response.Response{
+			response.New(registeredTypesModel{}, "200", "OK"),
+			response.New([]uuidLike{}, "206", "Partial"),
+		}),
+	))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]definition.SchemaProperty{
+		"id":        {Type: "string", Format: "uuid"},
+		"parent_id": {Type: "string", Format: "uuid", Nullable: true},
+		"related":   {Type: "array", Items: &definition.SchemaItems{Type: "string", Format: "uuid"}},
+		"avatar":    {Type: "string", Format: "byte"},
+		"raw":       {},
+		"addr":      {Type: "string", Format: "ip"},
+		"nick":      {Type: "string", Nullable: true},
+		"created":   {Type: "string", Format: "date-time"},
+	}
+	got := propertiesMap(openapi.Components.Schemas["swagno3.registeredTypesModel"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+	if len(openapi.Components.Schemas) != 1 {
+		t.Errorf("expected registered types to be documented inline, got schemas %v", openapi.Components.Schemas)
+	}
+
+	partial := openapi.Paths["/registered"].Get.Responses["206"].Content["application/json"].Schema
+	wantPartial := &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "string", Format: "uuid"}}
+	if diff := cmp.Diff(wantPartial, partial); diff != "" {
+		t.Errorf("response schema mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type providedStatus string
+
+func (providedStatus) SwaggerSchema() definition.Schema {
+	return definition.Schema{Type: "string", Enum: []interface{}{"active", "inactive"}}
+}
+
+type providedMoney struct {
+	Units    int64  `json:"units"`
+	Currency string `json:"currency"`
+}
+
+func (*providedMoney) SwaggerSchema() definition.Schema {
+	return definition.Schema{Type: "string", Pattern: `^\d+\.\d{2} [A-Z]{3}$`, Example: "12.50 EUR"}
+}
+
+type providedModel struct {
+	Status  providedStatus   `json:"status"`
+	Price   *providedMoney   `json:"price"`
+	History []providedStatus `json:"history"`
+}
+
+func TestSchemaProvider(t *testing.T) {
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	openapi.AddEndpoint(endpoint.New(
+		endpoint.POST,
+		"/provided",
+		endpoint.WithBody(providedModel{}),
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(providedStatus(""), "200", "OK")}),
+	))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	wantSchemas := map[string]definition.Schema{
+		"swagno3.providedStatus": {Type: "string", Enum: []interface{}{"active", "inactive"}},
+		"swagno3.providedMoney":  {Type: "string", Pattern: `^\d+\.\d{2} [A-Z]{3}$`, Example: "12.50 EUR"},
+	}
+	for name, want := range wantSchemas {
+		if diff := cmp.Diff(want, openapi.Components.Schemas[name]); diff != "" {
+			t.Errorf("schema %s mismatch (-want +got):\n%s", name, diff)
+		}
+	}
+
+	want := map[string]definition.SchemaProperty{
+		"status":  {Ref: "#/components/schemas/swagno3.providedStatus"},
+		"price":   {Ref: "#/components/schemas/swagno3.providedMoney", Nullable: true},
+		"history": {Type: "array", Items: &definition.SchemaItems{Ref: "#/components/schemas/swagno3.providedStatus"}},
+	}
+	got := propertiesMap(openapi.Components.Schemas["swagno3.providedModel"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+
+	schema := openapi.Paths["/provided"].Post.Responses["200"].Content["application/json"].Schema
+	if schema == nil || schema.Ref != "#/components/schemas/swagno3.providedStatus" {
+		t.Errorf("expected response to reference swagno3.providedStatus, got %+v", schema)
+	}
+}
+
+type orderStatus string
+
+const (
+	orderStatusNew  orderStatus = "new"
+	orderStatusPaid orderStatus = "paid"
+)
+
+func (orderStatus) Enum() []interface{} {
+	return []interface{}{orderStatusNew, orderStatusPaid}
+}
+
+func (orderStatus) EnumVarNames() []string {
+	return []string{"orderStatusNew", "orderStatusPaid"}
+}
+
+type orderPriority int
+
+type enumModel struct {
+	Status   orderStatus   `json:"status"`
+	Previous *orderStatus  `json:"previous"`
+	History  []orderStatus `json:"history"`
+	Priority orderPriority `json:"priority"`
+}
+
+func TestEnums(t *testing.T) {
+	newOpenAPI := func(enumSchemas bool) *OpenAPI {
+		cfg := Config{Title: "Testing API", Version: "v1.0.0", EnumSchemas: enumSchemas}
+		cfg.RegisterEnum(reflect.TypeOf(orderPriority(0)), fields.Enum{Values: []interface{}{1, 2, 3}})
+		openapi := New(cfg)
+		openapi.AddEndpoint(endpoint.New(
+			endpoint.POST,
+			"/orders",
+			endpoint.WithParams(
+				parameter.EnumParam("status", parameter.Query, orderStatus("")),
+				parameter.EnumParam("priority", parameter.Query, orderPriority(0)),
+			),
+			endpoint.WithBody(enumModel{}),
+			endpoint.WithSuccessfulReturns([]response.Response{
+				response.New([]orderStatus{}, "200", "OK"),
+				response.New(orderPriority(0), "201", "Created"),
+			}),
+		))
+		if err := openapi.generateOpenAPIJson(); err != nil {
+			t.Fatal(err)
+		}
+		return openapi
+	}
+	statusValues := []interface{}{orderStatusNew, orderStatusPaid}
+
+	t.Run("inline", func(t *testing.T) {
+		openapi := newOpenAPI(false)
+		want := map[string]definition.SchemaProperty{
+			"status":   {Type: "string", Enum: statusValues},
+			"previous": {Type: "string", Enum: statusValues, Nullable: true},
+			"history":  {Type: "array", Items: &definition.SchemaItems{Type: "string", Enum: statusValues}},
+			"priority": {Type: "integer", Format: "int64", Enum: []interface{}{1, 2, 3}},
+		}
+		got := propertiesMap(openapi.Components.Schemas["swagno3.enumModel"].Properties)
+		if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")); diff != "" {
+			t.Errorf("properties mismatch (-want +got):\n%s", diff)
+		}
+
+		params := openapi.Paths["/orders"].Post.Parameters
+		if diff := cmp.Diff(statusValues, params[0].Schema.Enum); diff != "" || params[0].Schema.Type != "string" {
+			t.Errorf("status parameter mismatch: %+v", params[0].Schema)
+		}
+		if diff := cmp.Diff([]interface{}{1, 2, 3}, params[1].Schema.Enum); diff != "" || params[1].Schema.Type != "integer" {
+			t.Errorf("priority parameter mismatch: %+v", params[1].Schema)
+		}
+
+		history := openapi.Paths["/orders"].Post.Responses["200"].Content["application/json"].Schema
+		if diff := cmp.Diff(statusValues, history.Items.Enum); diff != "" || history.Items.Type != "string" {
+			t.Errorf("history response mismatch: %+v", history.Items)
+		}
+		priority := openapi.Paths["/orders"].Post.Responses["201"].Content["application/json"].Schema
+		if diff := cmp.Diff([]interface{}{1, 2, 3}, priority.Enum); diff != "" || priority.Type != "integer" {
+			t.Errorf("priority response mismatch: %+v", priority)
+		}
+	})
+
+	t.Run("enum schemas", func(t *testing.T) {
+		openapi := newOpenAPI(true)
+		want := map[string]definition.SchemaProperty{
+			"status":   {Ref: "#/components/schemas/swagno3.orderStatus"},
+			"previous": {Ref: "#/components/schemas/swagno3.orderStatus", Nullable: true},
+			"history":  {Type: "array", Items: &definition.SchemaItems{Ref: "#/components/schemas/swagno3.orderStatus"}},
+			"priority": {Ref: "#/components/schemas/swagno3.orderPriority"},
+		}
+		got := propertiesMap(openapi.Components.Schemas["swagno3.enumModel"].Properties)
+		if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")); diff != "" {
+			t.Errorf("properties mismatch (-want +got):\n%s", diff)
+		}
+
+		wantStatus := definition.Schema{
+			Type:       "string",
+			Enum:       statusValues,
+			Extensions: extensions.Extensions{"x-enum-varnames": []string{"orderStatusNew", "orderStatusPaid"}},
+		}
+		if diff := cmp.Diff(wantStatus, openapi.Components.Schemas["swagno3.orderStatus"]); diff != "" {
+			t.Errorf("enum schema mismatch (-want +got):\n%s", diff)
+		}
+
+		if got := openapi.Paths["/orders"].Post.Responses["200"].Content["application/json"].Schema.Items.Ref; got != "#/components/schemas/swagno3.orderStatus" {
+			t.Errorf("expected history response items to reference the enum, got %q", got)
+		}
+		if got := openapi.Paths["/orders"].Post.Responses["201"].Content["application/json"].Schema.Ref; got != "#/components/schemas/swagno3.orderPriority" {
+			t.Errorf("expected priority response to reference the enum, got %q", got)
+		}
+	})
+}
+
+func TestSharedEnumParams(t *testing.T) {
+	priority := parameter.EnumParam("priority", parameter.Query, orderPriority(0))
+	generate := func(cfg Config) *OpenAPI {
+		openapi := New(cfg)
+		openapi.RegisterParameter("priority", priority)
+		openapi.AddEndpoint(endpoint.New(endpoint.GET, "/orders", endpoint.WithParams(priority)))
+		if err := openapi.generateOpenAPIJson(); err != nil {
+			t.Fatal(err)
+		}
+		return openapi
+	}
+
+	withEnums := Config{Title: "Testing API", Version: "v1.0.0"}
+	withEnums.RegisterEnum(reflect.TypeOf(orderPriority(0)), fields.Enum{Values: []interface{}{1, 2, 3}})
+	openapi := generate(withEnums)
+	if diff := cmp.Diff([]interface{}{1, 2, 3}, openapi.Paths["/orders"].Get.Parameters[0].Schema.Enum); diff != "" {
+		t.Errorf("parameter enum mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]interface{}{1, 2, 3}, openapi.Components.Parameters["priority"].Schema.Enum); diff != "" {
+		t.Errorf("registered parameter enum mismatch (-want +got):\n%s", diff)
+	}
+
+	openapi = generate(Config{Title: "Testing API", Version: "v1.0.0"})
+	if got := openapi.Paths["/orders"].Get.Parameters[0].Schema.Enum; got != nil {
+		t.Errorf("expected no parameter enum without registered enums, got %v", got)
+	}
+	if got := openapi.Components.Parameters["priority"].Schema.Enum; got != nil {
+		t.Errorf("expected no registered parameter enum without registered enums, got %v", got)
+	}
+}
+
+type shape interface {
+	Area() float64
+}
+
+type circle struct {
+	Kind   string  `json:"kind"`
+	Radius float64 `json:"radius"`
+}
+
+func (c circle) Area() float64 { return 3.14 * c.Radius * c.Radius }
+
+type square struct {
+	Kind string  `json:"kind"`
+	Side float64 `json:"side"`
+}
+
+func (s square) Area() float64 { return s.Side * s.Side }
+
+type drawing struct {
+	Main     shape     `json:"main"`
+	Shapes   []shape   `json:"shapes"`
+	Payload  any       `json:"payload"`
+	Extras   []any     `json:"extras"`
+	ShapeRef *shape    `json:"shape_ref"`
+	Anything *any      `json:"anything"`
+	OnDraw   *func()   `json:"on_draw"`
+	Events   *chan int `json:"events"`
+}
+
+func TestInterfaces(t *testing.T) {
+	cfg := Config{Title: "Testing API", Version: "v1.0.0"}
+	cfg.RegisterInterface(reflect.TypeOf((*shape)(nil)).Elem(), fields.Implementations{
+		PropertyName: "kind",
+		Mapping:      map[string]interface{}{"circle": circle{}, "square": square{}},
+	})
+	openapi := New(cfg)
+	openapi.AddEndpoint(endpoint.New(endpoint.POST, "/drawings", endpoint.WithBody(drawing{})))
+	if err := openapi.generateOpenAPIDefinition(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]definition.SchemaProperty{
+		"main":      {Ref: "#/components/schemas/swagno3.shape"},
+		"shapes":    {Type: "array", Items: &definition.SchemaItems{Ref: "#/components/schemas/swagno3.shape"}},
+		"payload":   {},
+		"extras":    {Type: "array", Items: &definition.SchemaItems{}},
+		"shape_ref": {Ref: "#/components/schemas/swagno3.shape", Nullable: true},
+		"anything":  {Nullable: true},
+	}
+	got := propertiesMap(openapi.Components.Schemas["swagno3.drawing"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+
+	wantShape := definition.Schema{
+		OneOf: []*definition.Schema{
+			{Ref: "#/components/schemas/swagno3.circle"},
+			{Ref: "#/components/schemas/swagno3.square"},
+		},
+		Discriminator: &definition.Discriminator{
+			PropertyName: "kind",
+			Mapping: map[string]string{
+				"circle": "#/components/schemas/swagno3.circle",
+				"square": "#/components/schemas/swagno3.square",
+			},
+		},
+	}
+	if diff := cmp.Diff(wantShape, openapi.Components.Schemas["swagno3.shape"]); diff != "" {
+		t.Errorf("interface schema mismatch (-want +got):\n%s", diff)
+	}
+	for _, name := range []string{"swagno3.circle", "swagno3.square"} {
+		if _, ok := openapi.Components.Schemas[name]; !ok {
+			t.Errorf("expected implementation schema %s", name)
+		}
+	}
+}
+
+type mapItem struct {
+	Name string `json:"name"`
+}
+
+type mapModel struct {
+	Counts   map[string]int          `json:"counts"`
+	Items    map[string]mapItem      `json:"items"`
+	Groups   map[string][]mapItem    `json:"groups"`
+	Batches  []map[string]*mapItem   `json:"batches"`
+	Labels   *map[string]string      `json:"labels"`
+	Metadata map[string]any          `json:"metadata"`
+	Nested   map[string]map[int]bool `json:"nested"`
+}
+
+func TestMaps(t *testing.T) {
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	openapi.AddEndpoint(endpoint.New(
+		endpoint.POST,
+		"/maps",
+		endpoint.WithBody(map[string][]mapItem{}),
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(mapModel{}, "200", "OK")}),
+		endpoint.WithErrors([]response.Response{response.New(map[string]mapItem{}, "400", "Bad Request")}),
+	))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	itemRef := &definition.SchemaProperty{Ref: "#/components/schemas/swagno3.mapItem"}
+	want := map[string]definition.SchemaProperty{
+		"counts":   {Type: "object", AdditionalProperties: &definition.SchemaProperty{Type: "integer", Format: "int64"}},
+		"items":    {Type: "object", AdditionalProperties: itemRef},
+		"groups":   {Type: "object", AdditionalProperties: &definition.SchemaProperty{Type: "array", Items: &definition.SchemaItems{Ref: "#/components/schemas/swagno3.mapItem"}}},
+		"batches":  {Type: "array", Items: &definition.SchemaItems{Type: "object", AdditionalProperties: itemRef}},
+		"labels":   {Type: "object", AdditionalProperties: &definition.SchemaProperty{Type: "string"}, Nullable: true},
+		"metadata": {Type: "object", AdditionalProperties: &definition.SchemaProperty{}},
+		"nested":   {Type: "object", AdditionalProperties: &definition.SchemaProperty{Type: "object", AdditionalProperties: &definition.SchemaProperty{Type: "boolean"}}},
+	}
+	got := propertiesMap(openapi.Components.Schemas["swagno3.mapModel"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+
+	var names []string
+	for name := range openapi.Components.Schemas {
+		names = append(names, name)
+	}
+	if diff := cmp.Diff([]string{"swagno3.mapItem", "swagno3.mapModel"}, names, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("schemas mismatch (-want +got):\n%s", diff)
+	}
+
+	operation := openapi.Paths["/maps"].Post
+	wantBody := &parameter.JsonResponseSchema{
+		Type:                 "object",
+		AdditionalProperties: &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Ref: "#/components/schemas/swagno3.mapItem"}},
+	}
+	if diff := cmp.Diff(wantBody, operation.RequestBody.Content["application/json"].Schema); diff != "" {
+		t.Errorf("request body schema mismatch (-want +got):\n%s", diff)
+	}
+	wantError := &parameter.JsonResponseSchema{
+		Type:                 "object",
+		AdditionalProperties: &parameter.JsonResponseSchema{Ref: "#/components/schemas/swagno3.mapItem"},
+	}
+	if diff := cmp.Diff(wantError, operation.Responses["400"].Content["application/json"].Schema); diff != "" {
+		t.Errorf("response schema mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type embeddedAudit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type embeddedBase struct {
+	embeddedAudit
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type embeddedMeta struct {
+	Version int `json:"version"`
+}
+
+type embeddedModel struct {
+	embeddedBase
+	*embeddedMeta
+	Owner embeddedAudit `json:"owner"`
+	Name  string        `json:"name" example:"shadowed"`
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	newOpenAPI := func(allOf bool) *OpenAPI {
+		openapi := New(Config{Title: "Testing API", Version: "v1.0.0", EmbeddedAllOf: allOf})
+		openapi.AddEndpoint(endpoint.New(
+			endpoint.GET,
+			"/embedded",
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(embeddedModel{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(embeddedBase{}, "400", "Bad Request")}),
+		))
+		if err := openapi.generateOpenAPIJson(); err != nil {
+			t.Fatal(err)
+		}
+		return openapi
+	}
+	ignoreRequired := cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")
+
+	t.Run("flatten", func(t *testing.T) {
+		openapi := newOpenAPI(false)
+		want := definition.Schema{
+			Type: "object",
+			Properties: definition.Properties{
+				{Name: "created_by", Schema: definition.SchemaProperty{Type: "string"}},
+				{Name: "id", Schema: definition.SchemaProperty{Type: "integer", Format: "int64"}},
+				{Name: "version", Schema: definition.SchemaProperty{Type: "integer", Format: "int64"}},
+				{Name: "owner", Schema: definition.SchemaProperty{Ref: "#/components/schemas/swagno3.embeddedAudit"}},
+				{Name: "name", Schema: definition.SchemaProperty{Type: "string", Example: "shadowed"}},
+			},
+			Required: []string{"created_by", "id", "version", "owner", "name"},
+		}
+		got := openapi.Components.Schemas["swagno3.embeddedModel"]
+		if diff := cmp.Diff(want, got, ignoreRequired); diff != "" {
+			t.Errorf("schema mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("allOf", func(t *testing.T) {
+		openapi := newOpenAPI(true)
+		// embeddedModel shadows the name of embeddedBase, whose other fields are flattened
+		want := definition.Schema{
+			AllOf: []*definition.Schema{
+				{Ref: "#/components/schemas/swagno3.embeddedMeta"},
+				{
+					Type: "object",
+					Properties: definition.Properties{
+						{Name: "created_by", Schema: definition.SchemaProperty{Type: "string"}},
+						{Name: "id", Schema: definition.SchemaProperty{Type: "integer", Format: "int64"}},
+						{Name: "owner", Schema: definition.SchemaProperty{Ref: "#/components/schemas/swagno3.embeddedAudit"}},
+						{Name: "name", Schema: definition.SchemaProperty{Type: "string", Example: "shadowed"}},
+					},
+					Required: []string{"created_by", "id", "owner", "name"},
+				},
+			},
+		}
+		got := openapi.Components.Schemas["swagno3.embeddedModel"]
+		if diff := cmp.Diff(want, got, ignoreRequired); diff != "" {
+			t.Errorf("schema mismatch (-want +got):\n%s", diff)
+		}
+
+		wantBase := definition.Schema{
+			AllOf: []*definition.Schema{
+				{Ref: "#/components/schemas/swagno3.embeddedAudit"},
+				{
+					Type: "object",
+					Properties: definition.Properties{
+						{Name: "id", Schema: definition.SchemaProperty{Type: "integer", Format: "int64"}},
+						{Name: "name", Schema: definition.SchemaProperty{Type: "string"}},
+					},
+					Required: []string{"id", "name"},
+				},
+			},
+		}
+		if diff := cmp.Diff(wantBase, openapi.Components.Schemas["swagno3.embeddedBase"], ignoreRequired); diff != "" {
+			t.Errorf("base schema mismatch (-want +got):\n%s", diff)
+		}
+		if _, ok := openapi.Components.Schemas["swagno3.embeddedMeta"]; !ok {
+			t.Error("expected schema for the embedded pointer struct")
+		}
+	})
+}
+
+type shadowedBase struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type shadowedTop struct {
+	shadowedBase
+	ID string `json:"id"`
+}
+
+func TestEmbeddedAllOfShadowing(t *testing.T) {
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0", EmbeddedAllOf: true})
+	openapi.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/shadowed",
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(shadowedTop{}, "200", "OK")}),
+	))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := definition.Schema{
+		Type: "object",
+		Properties: definition.Properties{
+			{Name: "name", Schema: definition.SchemaProperty{Type: "string"}},
+			{Name: "id", Schema: definition.SchemaProperty{Type: "string"}},
+		},
+		Required: []string{"name", "id"},
+	}
+	got := openapi.Components.Schemas["swagno3.shadowedTop"]
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")); diff != "" {
+		t.Errorf("schema mismatch (-want +got):\n%s", diff)
+	}
+	if _, ok := openapi.Components.Schemas["swagno3.shadowedBase"]; ok {
+		t.Error("expected no schema for the shadowed embedded struct")
+	}
+}
+
+type genericItem struct {
+	Name string `json:"name"`
+}
+
+type genericPage[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+type genericCatalog struct {
+	Featured genericPage[genericItem] `json:"featured"`
+}
+
+func TestGenericTypes(t *testing.T) {
+	newOpenAPI := func(generic fields.GenericNameFunc) *OpenAPI {
+		openapi := New(Config{Title: "Testing API", Version: "v1.0.0", HidePackageName: true, GenericName: generic})
+		openapi.AddEndpoint(endpoint.New(
+			endpoint.POST,
+			"/pages",
+			endpoint.WithBody(genericPage[genericItem]{}),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(genericCatalog{}, "200", "OK")}),
+			endpoint.WithErrors([]response.Response{response.New(genericPage[string]{}, "400", "Bad Request")}),
+		))
+		return openapi
+	}
+
+	t.Run("default names", func(t *testing.T) {
+		openapi := newOpenAPI(nil)
+		if err := openapi.generateOpenAPIJson(); err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for name := range openapi.Components.Schemas {
+			names = append(names, name)
+		}
+		want := []string{"genericCatalog", "genericItem", "genericPage_genericItem", "genericPage_string"}
+		if diff := cmp.Diff(want, names, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+			t.Errorf("schemas mismatch (-want +got):\n%s", diff)
+		}
+
+		operation := openapi.Paths["/pages"].Post
+		if got := operation.RequestBody.Content["application/json"].Schema.Ref; got != "#/components/schemas/genericPage_genericItem" {
+			t.Errorf("unexpected request body reference %q", got)
+		}
+		if got := operation.Responses["400"].Content["application/json"].Schema.Ref; got != "#/components/schemas/genericPage_string" {
+			t.Errorf("unexpected response reference %q", got)
+		}
+		if got := propertiesMap(openapi.Components.Schemas["genericCatalog"].Properties)["featured"].Ref; got != "#/components/schemas/genericPage_genericItem" {
+			t.Errorf("unexpected property reference %q", got)
+		}
+	})
+
+	t.Run("custom names collide", func(t *testing.T) {
+		_, err := newOpenAPI(func(name string, typeArgs []string) string {
+			return name + "Page"
+		}).ToJson()
+		var collisionErr *NameCollisionError
+		if !errors.As(err, &collisionErr) {
+			t.Fatalf("expected *NameCollisionError, got %T: %v", err, err)
+		}
+		if _, ok := collisionErr.Collisions["genericPagePage"]; !ok {
+			t.Errorf("expected a collision on genericPagePage, got %v", collisionErr.Collisions)
+		}
+	})
+}
+
+type namedOwner struct {
+	Name string `json:"name"`
+}
+
+type namedModel struct {
+	Owner  namedOwner   `json:"owner"`
+	Owners []namedOwner `json:"owners"`
+}
+
+func TestNameStrategy(t *testing.T) {
+	tests := []struct {
+		name      string
+		strategy  fields.NameStrategy
+		wantModel string
+		wantOwner string
+	}{
+		{"qualified", nil, "swagno3.namedModel", "swagno3.namedOwner"},
+		{"import path", fields.ImportPathNames(), "github.com.go-swagno.swagno.v3.namedModel", "github.com.go-swagno.swagno.v3.namedOwner"},
+		{"path segments", fields.PathSegmentNames(2), "swagno.v3.namedModel", "swagno.v3.namedOwner"},
+		{"package prefix", fields.PackagePrefixNames(map[string]string{"github.com/go-swagno/swagno/v3": "Api"}), "ApinamedModel", "ApinamedOwner"},
+		{"callback", fields.NameFunc(func(t reflect.Type) string { return strings.ToUpper(t.Name()) }), "NAMEDMODEL", "NAMEDOWNER"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			openapi := New(Config{Title: "Testing API", Version: "v1.0.0", NameStrategy: tt.strategy})
+			openapi.AddEndpoint(endpoint.New(
+				endpoint.POST,
+				"/models",
+				endpoint.WithBody(namedModel{}),
+				endpoint.WithSuccessfulReturns([]response.Response{response.New([]namedModel{}, "200", "OK")}),
+				endpoint.WithErrors([]response.Response{response.New(namedOwner{}, "400", "Bad Request")}),
+			))
+			if err := openapi.generateOpenAPIJson(); err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+			for name := range openapi.Components.Schemas {
+				names = append(names, name)
+			}
+			if diff := cmp.Diff([]string{tt.wantModel, tt.wantOwner}, names, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("schemas mismatch (-want +got):\n%s", diff)
+			}
+
+			operation := openapi.Paths["/models"].Post
+			refs := map[string]string{
+				"body":     operation.RequestBody.Content["application/json"].Schema.Ref,
+				"response": operation.Responses["200"].Content["application/json"].Schema.Items.Ref,
+				"error":    operation.Responses["400"].Content["application/json"].Schema.Ref,
+				"property": propertiesMap(openapi.Components.Schemas[tt.wantModel].Properties)["owner"].Ref,
+				"items":    propertiesMap(openapi.Components.Schemas[tt.wantModel].Properties)["owners"].Items.Ref,
+			}
+			wantRefs := map[string]string{
+				"body":     "#/components/schemas/" + tt.wantModel,
+				"response": "#/components/schemas/" + tt.wantModel,
+				"error":    "#/components/schemas/" + tt.wantOwner,
+				"property": "#/components/schemas/" + tt.wantOwner,
+				"items":    "#/components/schemas/" + tt.wantOwner,
+			}
+			if diff := cmp.Diff(wantRefs, refs); diff != "" {
+				t.Errorf("references mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("collision", func(t *testing.T) {
+		openapi := New(Config{Title: "Testing API", Version: "v1.0.0", NameStrategy: fields.NameFunc(func(reflect.Type) string { return "Model" })})
+		openapi.AddEndpoint(endpoint.New(endpoint.POST, "/models", endpoint.WithBody(namedModel{})))
+
+		_, err := openapi.ToJson()
+		var collisionErr *NameCollisionError
+		if !errors.As(err, &collisionErr) {
+			t.Fatalf("expected *NameCollisionError, got %T: %v", err, err)
+		}
+	})
+}
+
+type treeNode struct {
+	Value    string      `json:"value"`
+	Children []*treeNode `json:"children"`
+	Parent   *treeNode   `json:"parent"`
+}
+
+type graphNode struct {
+	ID    int         `json:"id"`
+	Edges []graphEdge `json:"edges"`
+}
+
+type graphEdge struct {
+	From *graphNode           `json:"from"`
+	To   *graphNode           `json:"to"`
+	Next map[string]graphEdge `json:"next"`
+}
+
+type listNode struct {
+	Value int       `json:"value"`
+	Next  *listNode `json:"next"`
+}
+
+func TestRecursiveTypes(t *testing.T) {
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	openapi.AddEndpoint(endpoint.New(
+		endpoint.POST,
+		"/recursive",
+		endpoint.WithBody(treeNode{}),
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(graphNode{}, "200", "OK")}),
+		endpoint.WithErrors([]response.Response{response.New([]listNode{}, "400", "Bad Request")}),
+	))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	ref := func(name string) string { return "#/components/schemas/swagno3." + name }
+	want := map[string]map[string]definition.SchemaProperty{
+		"swagno3.treeNode": {
+			"value":    {Type: "string"},
+			"children": {Type: "array", Items: &definition.SchemaItems{Ref: ref("treeNode")}},
+			"parent":   {Ref: ref("treeNode"), Nullable: true},
+		},
+		"swagno3.graphNode": {
+			"id":    {Type: "integer", Format: "int64"},
+			"edges": {Type: "array", Items: &definition.SchemaItems{Ref: ref("graphEdge")}},
+		},
+		"swagno3.graphEdge": {
+			"from": {Ref: ref("graphNode"), Nullable: true},
+			"to":   {Ref: ref("graphNode"), Nullable: true},
+			"next": {Type: "object", AdditionalProperties: &definition.SchemaProperty{Ref: ref("graphEdge")}},
+		},
+		"swagno3.listNode": {
+			"value": {Type: "integer", Format: "int64"},
+			"next":  {Ref: ref("listNode"), Nullable: true},
+		},
+	}
+	got := map[string]map[string]definition.SchemaProperty{}
+	for name, schema := range openapi.Components.Schemas {
+		got[name] = propertiesMap(schema.Properties)
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")); diff != "" {
+		t.Errorf("schemas mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type orderedTimestamps struct {
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type orderedModel struct {
+	Zeta  string `json:"zeta" validate:"required"`
+	Alpha int    `json:"alpha,omitempty"`
+	orderedTimestamps
+	Mid bool `json:"mid" validate:"required"`
+}
+
+func TestPropertyOrder(t *testing.T) {
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	openapi.AddEndpoint(endpoint.New(endpoint.POST, "/ordered", endpoint.WithBody(orderedModel{})))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	def := openapi.Components.Schemas["swagno3.orderedModel"]
+	if diff := cmp.Diff([]string{"zeta", "alpha", "created_at", "updated_at", "mid"}, def.Properties.Names()); diff != "" {
+		t.Errorf("properties order mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"zeta", "created_at", "updated_at", "mid"}, def.Required); diff != "" {
+		t.Errorf("required order mismatch (-want +got):\n%s", diff)
+	}
+
+	data, err := json.Marshal(def.Properties)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"zeta":{"type":"string"},"alpha":{"type":"integer","format":"int64"},"created_at":{"type":"string"},"updated_at":{"type":"string"},"mid":{"type":"boolean"}}`
+	if string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+
+	var decoded definition.Properties
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(def.Properties, decoded, cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type numericModel struct {
+	Small   int8               `json:"small"`
+	Medium  int32              `json:"medium"`
+	Large   int                `json:"large"`
+	Huge    int64              `json:"huge"`
+	Count   uint               `json:"count"`
+	Octet   uint8              `json:"octet"`
+	Ratio   float32            `json:"ratio"`
+	Amount  float64            `json:"amount"`
+	Ports   []uint16           `json:"ports"`
+	Weights map[string]float32 `json:"weights"`
+	Limit   *uint32            `json:"limit"`
+}
+
+func TestNumericFormats(t *testing.T) {
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	openapi.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/numbers",
+		endpoint.WithParams(parameter.EnumParam("priority", parameter.Query, orderPriority(0))),
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(map[string][]uint64{}, "200", "OK")}),
+		endpoint.WithErrors([]response.Response{response.New(numericModel{}, "400", "Bad Request")}),
+	))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	zero := new(float64)
+	want := map[string]definition.SchemaProperty{
+		"small":   {Type: "integer", Format: "int32"},
+		"medium":  {Type: "integer", Format: "int32"},
+		"large":   {Type: "integer", Format: "int64"},
+		"huge":    {Type: "integer", Format: "int64"},
+		"count":   {Type: "integer", Format: "int64", Minimum: zero},
+		"octet":   {Type: "integer", Format: "int32", Minimum: zero},
+		"ratio":   {Type: "number", Format: "float"},
+		"amount":  {Type: "number", Format: "double"},
+		"ports":   {Type: "array", Items: &definition.SchemaItems{Type: "integer", Format: "int32", Minimum: zero}},
+		"weights": {Type: "object", AdditionalProperties: &definition.SchemaProperty{Type: "number", Format: "float"}},
+		"limit":   {Type: "integer", Format: "int64", Minimum: zero, Nullable: true},
+	}
+	got := propertiesMap(openapi.Components.Schemas["swagno3.numericModel"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+
+	operation := openapi.Paths["/numbers"].Get
+	wantResponse := &parameter.JsonResponseSchema{
+		Type: "object",
+		AdditionalProperties: &parameter.JsonResponseSchema{
+			Type:  "array",
+			Items: &parameter.JsonResponseSchemeItems{Type: "integer", Format: "int64", Min: zero},
+		},
+	}
+	if diff := cmp.Diff(wantResponse, operation.Responses["200"].Content["application/json"].Schema); diff != "" {
+		t.Errorf("response schema mismatch (-want +got):\n%s", diff)
+	}
+	if format := operation.Parameters[0].Schema.Format; format != "int64" {
+		t.Errorf("expected enum parameter format int64, got %q", format)
+	}
+}
+
+type jsonRulesDeep struct {
+	Level string `json:"level"`
+}
+
+type jsonRulesLeft struct {
+	jsonRulesDeep
+	Shared string
+	Label  string `json:"Kind"`
+}
+
+type jsonRulesRight struct {
+	Shared string
+	Kind   string
+	Level  int `json:"level"`
+}
+
+type jsonRulesModel struct {
+	Plain  string
+	secret string
+	ID     int64  `json:"id,string"`
+	Flag   bool   `json:",string"`
+	Note   string `json:",omitempty"`
+	Skip   string `json:"-"`
+	Dash   string `json:"-,"`
+	jsonRulesLeft
+	jsonRulesRight
+}
+
+func TestJSONFieldRules(t *testing.T) {
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	openapi.AddEndpoint(endpoint.New(endpoint.POST, "/json", endpoint.WithBody(jsonRulesModel{})))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	def := openapi.Components.Schemas["swagno3.jsonRulesModel"]
+	want := definition.Properties{
+		{Name: "Plain", Schema: definition.SchemaProperty{Type: "string"}},
+		{Name: "id", Schema: definition.SchemaProperty{Type: "string"}},
+		{Name: "Flag", Schema: definition.SchemaProperty{Type: "string"}},
+		{Name: "Note", Schema: definition.SchemaProperty{Type: "string"}},
+		{Name: "-", Schema: definition.SchemaProperty{Type: "string"}},
+		{Name: "Kind", Schema: definition.SchemaProperty{Type: "string"}},
+		{Name: "level", Schema: definition.SchemaProperty{Type: "integer", Format: "int64"}},
+	}
+	if diff := cmp.Diff(want, def.Properties, cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"Plain", "id", "Flag", "-", "Kind", "level"}, def.Required); diff != "" {
+		t.Errorf("required mismatch (-want +got):\n%s", diff)
+	}
+
+	// the documented properties are the keys encoding/json writes
+	data, err := json.Marshal(jsonRulesModel{Note: "set"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var encoded map[string]interface{}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for key := range encoded {
+		keys = append(keys, key)
+	}
+	if diff := cmp.Diff(def.Properties.Names(), keys, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("encoded keys mismatch (-documented +encoded):\n%s", diff)
+	}
+}
+
+type anonymousModel struct {
+	Meta struct {
+		Version int `json:"version"`
+	} `json:"meta"`
+	Owner *struct {
+		Name string `json:"name"`
+	} `json:"owner"`
+	Lines []struct {
+		SKU string `json:"sku"`
+		Qty int    `json:"qty,omitempty"`
+	} `json:"lines"`
+	Notes map[string]struct {
+		Text string `json:"text"`
+	} `json:"notes"`
+}
+
+func TestAnonymousStructs(t *testing.T) {
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	openapi.AddEndpoint(endpoint.New(endpoint.POST, "/anonymous", endpoint.WithBody(anonymousModel{})))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	object := func(required []string, properties ...definition.Property) definition.SchemaProperty {
+		return definition.SchemaProperty{Type: "object", Properties: properties, Required: required}
+	}
+	owner := object([]string{"name"}, definition.Property{Name: "name", Schema: definition.SchemaProperty{Type: "string"}})
+	owner.Nullable = true
+	line := object([]string{"sku"},
+		definition.Property{Name: "sku", Schema: definition.SchemaProperty{Type: "string"}},
+		definition.Property{Name: "qty", Schema: definition.SchemaProperty{Type: "integer", Format: "int64"}},
+	)
+	note := object([]string{"text"}, definition.Property{Name: "text", Schema: definition.SchemaProperty{Type: "string"}})
+	want := map[string]definition.SchemaProperty{
+		"meta":  object([]string{"version"}, definition.Property{Name: "version", Schema: definition.SchemaProperty{Type: "integer", Format: "int64"}}),
+		"owner": owner,
+		"lines": {Type: "array", Items: &definition.SchemaItems{Type: line.Type, Properties: line.Properties, Required: line.Required}},
+		"notes": {Type: "object", AdditionalProperties: &note},
+	}
+	got := propertiesMap(openapi.Components.Schemas["swagno3.anonymousModel"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+
+	var names []string
+	for name := range openapi.Components.Schemas {
+		names = append(names, name)
+	}
+	if diff := cmp.Diff([]string{"swagno3.anonymousModel"}, names); diff != "" {
+		t.Errorf("definitions mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAnonymousStructResponses(t *testing.T) {
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	openapi.AddEndpoint(endpoint.New(
+		endpoint.POST,
+		"/anonymous",
+		endpoint.WithBody(struct {
+			Name  string       `json:"name" example:"Ada" desc:"Display name" validate:"max=20"`
+			Point *nestedPoint `json:"point"`
+			Label *string      `json:"label"`
+		}{}),
+		endpoint.WithSuccessfulReturns([]response.Response{response.New([]struct {
+			ID    int          `json:"id"`
+			Point *nestedPoint `json:"point,omitempty"`
+		}{}, "200", "OK")}),
+		endpoint.WithErrors([]response.Response{response.New(struct {
+			Message string `json:"message"`
+		}{}, "400", "Bad Request")}),
+	))
+	data, err := openapi.ToJson()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operation := openapi.Paths["/anonymous"].Post
+	for _, tc := range []struct {
+		name   string
+		schema *parameter.JsonResponseSchema
+		want   string
+	}{
+		{"body", operation.RequestBody.Content["application/json"].Schema, `{"type":"object","properties":{"name":{"type":"string","example":"Ada","description":"Display name","maxLength":20},"point":{"$ref":"#/components/schemas/swagno3.nestedPoint","nullable":true},"label":{"type":"string","nullable":true}},"required":["name"]}`},
+		{"response", operation.Responses["200"].Content["application/json"].Schema, `{"type":"array","items":{"type":"object","properties":{"id":{"type":"integer","format":"int64"},"point":{"$ref":"#/components/schemas/swagno3.nestedPoint","nullable":true}},"required":["id"]}}`},
+		{"error", operation.Responses["400"].Content["application/json"].Schema, `{"type":"object","properties":{"message":{"type":"string"}},"required":["message"]}`},
+	} {
+		got, err := json.Marshal(tc.schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("%s schema = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if dangling := danglingRefs(doc, doc); len(dangling) > 0 {
+		t.Errorf("references not resolving in the document: %v", dangling)
+	}
+}
+
+func TestBodyJsonParameter(t *testing.T) {
+	e := endpoint.New(endpoint.POST, "/points", endpoint.WithBody(nestedPoint{}))
+	if got := e.BodyJsonParameter(true).Schema.Ref; got != "#/components/schemas/nestedPoint" {
+		t.Errorf("body schema ref = %q, want %q", got, "#/components/schemas/nestedPoint")
+	}
+}
+
+// danglingRefs returns the '$ref' values found in value which do not resolve to a
+// value of the decoded document doc.
+func danglingRefs(doc, value interface{}) []string {
+	var dangling []string
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok {
+			var target interface{} = doc
+			for _, segment := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+				object, _ := target.(map[string]interface{})
+				target = object[strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)]
+			}
+			if target == nil {
+				dangling = append(dangling, ref)
+			}
+		}
+		for _, child := range value {
+			dangling = append(dangling, danglingRefs(doc, child)...)
+		}
+	case []interface{}:
+		for _, child := range value {
+			dangling = append(dangling, danglingRefs(doc, child)...)
+		}
+	}
+	return dangling
+}
+
+type nestedPoint struct {
+	X int `json:"x"`
+}
+
+type nestedArraysModel struct {
+	Matrix  [][]int              `json:"matrix"`
+	Paths   [][]nestedPoint      `json:"paths"`
+	Groups  []*[]string          `json:"groups"`
+	Lookups []map[string][]int32 `json:"lookups"`
+	RGB     [3]uint8             `json:"rgb"`
+	Grid    *[2][2]float64       `json:"grid"`
+}
+
+func TestNestedArrays(t *testing.T) {
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	openapi.AddEndpoint(endpoint.New(
+		endpoint.POST,
+		"/nested",
+		endpoint.WithBody([][3]int{}),
+		endpoint.WithSuccessfulReturns([]response.Response{response.New([][]nestedPoint{}, "200", "OK")}),
+		endpoint.WithErrors([]response.Response{response.New(nestedArraysModel{}, "400", "Bad Request")}),
+	))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	two, three := int64(2), int64(3)
+	point := "#/components/schemas/swagno3.nestedPoint"
+	want := map[string]definition.SchemaProperty{
+		"matrix": {Type: "array", Items: &definition.SchemaItems{Type: "array", Items: &definition.SchemaItems{Type: "integer", Format: "int64"}}},
+		"paths":  {Type: "array", Items: &definition.SchemaItems{Type: "array", Items: &definition.SchemaItems{Ref: point}}},
+		"groups": {Type: "array", Items: &definition.SchemaItems{Type: "array", Items: &definition.SchemaItems{Type: "string"}}},
+		"lookups": {Type: "array", Items: &definition.SchemaItems{
+			Type:                 "object",
+			AdditionalProperties: &definition.SchemaProperty{Type: "array", Items: &definition.SchemaItems{Type: "integer", Format: "int32"}},
+		}},
+		"rgb": {Type: "array", Items: &definition.SchemaItems{Type: "integer", Format: "int32", Minimum: new(float64)}, MinItems: &three, MaxItems: &three},
+		"grid": {
+			Type:     "array",
+			Items:    &definition.SchemaItems{Type: "array", Items: &definition.SchemaItems{Type: "number", Format: "double"}, MinItems: &two, MaxItems: &two},
+			MinItems: &two,
+			MaxItems: &two, Nullable: true,
+		},
+	}
+	got := propertiesMap(openapi.Components.Schemas["swagno3.nestedArraysModel"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+
+	operation := openapi.Paths["/nested"].Post
+	wantBody := &parameter.JsonResponseSchema{
+		Type:  "array",
+		Items: &parameter.JsonResponseSchemeItems{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "integer", Format: "int64"}, MinItems: &three, MaxItems: &three},
+	}
+	if diff := cmp.Diff(wantBody, operation.RequestBody.Content["application/json"].Schema); diff != "" {
+		t.Errorf("body schema mismatch (-want +got):\n%s", diff)
+	}
+	wantResponse := &parameter.JsonResponseSchema{
+		Type:  "array",
+		Items: &parameter.JsonResponseSchemeItems{Type: "array", Items: &parameter.JsonResponseSchemeItems{Ref: point}},
+	}
+	if diff := cmp.Diff(wantResponse, operation.Responses["200"].Content["application/json"].Schema); diff != "" {
+		t.Errorf("response schema mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPrimitiveModels(t *testing.T) {
+	zero := new(float64)
+	tests := []struct {
+		name  string
+		model any
+		want  *parameter.JsonResponseSchema
+	}{
+		{"string", "", &parameter.JsonResponseSchema{Type: "string"}},
+		{"int", 0, &parameter.JsonResponseSchema{Type: "integer", Format: "int64"}},
+		{"uint8", uint8(0), &parameter.JsonResponseSchema{Type: "integer", Format: "int32", Min: zero}},
+		{"bool pointer", new(bool), &parameter.JsonResponseSchema{Type: "boolean"}},
+		{"bytes", []byte{}, &parameter.JsonResponseSchema{Type: "string", Format: "byte"}},
+		{"time", time.Time{}, &parameter.JsonResponseSchema{Type: "string", Format: "date-time"}},
+		{"slice", []string{}, &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "string"}}},
+		{"map", map[string]int{}, &parameter.JsonResponseSchema{Type: "object", AdditionalProperties: &parameter.JsonResponseSchema{Type: "integer", Format: "int64"}}},
+		{"struct pointer", &nestedPoint{}, &parameter.JsonResponseSchema{Ref: "#/components/schemas/swagno3.nestedPoint"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+			path := "/" + strings.ReplaceAll(tt.name, " ", "-")
+			openapi.AddEndpoint(endpoint.New(
+				endpoint.POST,
+				path,
+				endpoint.WithBody(tt.model),
+				endpoint.WithSuccessfulReturns([]response.Response{response.New(tt.model, "200", "OK")}),
+			))
+			if err := openapi.generateOpenAPIJson(); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, openapi.Paths[path].Post.Responses["200"].Content["application/json"].Schema); diff != "" {
+				t.Errorf("response schema mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.want, openapi.Paths[path].Post.RequestBody.Content["application/json"].Schema); diff != "" {
+				t.Errorf("body schema mismatch (-want +got):\n%s", diff)
+			}
+			names := []string{}
+			for name := range openapi.Components.Schemas {
+				names = append(names, name)
+			}
+			if tt.want.Ref == "" && len(names) > 0 {
+				t.Errorf("unexpected schemas %v", names)
+			}
+		})
+	}
+}
+
+type documentedModel struct {
+	ID       string       `json:"id" format:"uuid" readonly:"true"`
+	Limit    int          `json:"limit" default:"10"`
+	Ratio    *float32     `json:"ratio" default:"0.5" nullable:"true"`
+	Enabled  bool         `json:"enabled" default:"true"`
+	Tags     []string     `json:"tags" default:"[\"a\",\"b\"]"`
+	Password string       `json:"password" writeonly:"true" pattern:"^[a-z]+$"`
+	Legacy   string       `json:"legacy" deprecated:"true" default:"x"`
+	Count    int64        `json:"count,string" default:"3"`
+	Since    time.Time    `json:"since" default:"2024-01-01T00:00:00Z"`
+	Owner    *nestedPoint `json:"owner" readonly:"true"`
+}
+
+func TestDocumentationTags(t *testing.T) {
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	openapi.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/documented",
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(documentedModel{}, "200", "OK")}),
+	))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]definition.SchemaProperty{
+		"id":       {Type: "string", Format: "uuid", ReadOnly: true},
+		"limit":    {Type: "integer", Format: "int64", Default: int64(10)},
+		"ratio":    {Type: "number", Format: "float", Default: 0.5, Nullable: true},
+		"enabled":  {Type: "boolean", Default: true},
+		"tags":     {Type: "array", Items: &definition.SchemaItems{Type: "string"}, Default: []string{"a", "b"}},
+		"password": {Type: "string", Pattern: "^[a-z]+$", WriteOnly: true},
+		"legacy":   {Type: "string", Default: "x", Deprecated: true},
+		"count":    {Type: "string", Default: "3"},
+		"since":    {Type: "string", Format: "date-time", Default: "2024-01-01T00:00:00Z"},
+		"owner":    {Ref: "#/components/schemas/swagno3.nestedPoint", Nullable: true},
+	}
+	got := propertiesMap(openapi.Components.Schemas["swagno3.documentedModel"].Properties)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(definition.SchemaProperty{}, "IsRequired")); diff != "" {
+		t.Errorf("properties mismatch (-want +got):\n%s", diff)
+	}
+
+	data, err := json.Marshal(got["legacy"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"deprecated":true`) {
+		t.Errorf("legacy property %s does not mark deprecation", data)
+	}
+}
+
+type typedExamplesModel struct {
+	Code    string            `json:"code" example:"0123"`
+	Offset  int               `json:"offset" example:"-5"`
+	Ratio   float64           `json:"ratio" example:"0.25"`
+	Active  bool              `json:"active" example:"true"`
+	IDs     []int             `json:"ids" example:"[1,2,3]"`
+	Labels  map[string]string `json:"labels" example:"{\"env\":\"prod\"}"`
+	Since   time.Time         `json:"since" example:"2024-01-01T00:00:00Z"`
+	Count   *uint8            `json:"count" example:"7"`
+	Version int64             `json:"version,string" example:"3"`
+}
+
+type invalidExamplesModel struct {
+	Age int   `json:"age" example:"old"`
+	IDs []int `json:"ids" example:"[1,"`
+}
+
+func TestTypedExamples(t *testing.T) {
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	openapi.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/examples",
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(typedExamplesModel{}, "200", "OK")}),
+	))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"code":    "0123",
+		"offset":  int64(-5),
+		"ratio":   0.25,
+		"active":  true,
+		"ids":     []int{1, 2, 3},
+		"labels":  map[string]string{"env": "prod"},
+		"since":   "2024-01-01T00:00:00Z",
+		"count":   uint64(7),
+		"version": "3",
+	}
+	got := map[string]interface{}{}
+	for name, property := range propertiesMap(openapi.Components.Schemas["swagno3.typedExamplesModel"].Properties) {
+		got[name] = property.Example
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("examples mismatch (-want +got):\n%s", diff)
+	}
+
+	newInvalid := func(strict bool) *OpenAPI {
+		invalid := New(Config{Title: "Testing API", Version: "v1.0.0", StrictExamples: strict})
+		invalid.AddEndpoint(endpoint.New(
+			endpoint.GET,
+			"/invalid",
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(invalidExamplesModel{}, "200", "OK")}),
+		))
+		return invalid
+	}
+	checkErrors := func(t *testing.T, errs []error) {
+		if len(errs) != 2 {
+			t.Fatalf("expected 2 example errors, got %v", errs)
+		}
+		for i, want := range []string{`example "[1," of field IDs is not a valid []int`, `example "old" of field Age is not a valid int`} {
+			if msg := errs[i].Error(); !strings.Contains(msg, want) {
+				t.Errorf("error %d: %q does not contain %q", i, msg, want)
+			}
+		}
+	}
+
+	t.Run("invalid examples are reported", func(t *testing.T) {
+		invalid := newInvalid(false)
+		if _, err := invalid.ToJson(); err != nil {
+			t.Fatalf("expected invalid examples not to fail, got %v", err)
+		}
+		checkErrors(t, invalid.ExampleErrors())
+		got := propertiesMap(invalid.Components.Schemas["swagno3.invalidExamplesModel"].Properties)
+		if got["age"].Example != "old" || got["ids"].Example != "[1," {
+			t.Errorf("expected the examples as written, got %v and %v", got["age"].Example, got["ids"].Example)
+		}
+	})
+
+	t.Run("strict examples", func(t *testing.T) {
+		_, err := newInvalid(true).ToJson()
+		var exampleErr *ExampleError
+		if !errors.As(err, &exampleErr) {
+			t.Fatalf("expected *ExampleError, got %v", err)
+		}
+		checkErrors(t, exampleErr.Errors)
+	})
+}
+
+type commentedBase struct {
+	ID int `json:"id"`
+}
+
+type commentedModel struct {
+	commentedBase
+	Email string `json:"email"`
+	Nick  string `json:"nick" desc:"Explicit description"`
+	Plain string `json:"plain"`
+}
+
+func TestDocComments(t *testing.T) {
+	pkgPath := reflect.TypeOf(commentedModel{}).PkgPath()
+	comments := fields.Comments{
+		pkgPath + ".commentedModel":       "A model described by its doc comment.",
+		pkgPath + ".commentedModel.Email": "Email doc.",
+		pkgPath + ".commentedModel.Nick":  "Nick doc.",
+		pkgPath + ".commentedBase.ID":     "ID doc.",
+	}
+	data, err := comments.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed, err := fields.ParseComments(data); err != nil || !cmp.Equal(comments, parsed) {
+		t.Errorf("ParseComments() = %v, %v; want %v", parsed, err, comments)
+	}
+
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0", Comments: comments})
+	openapi.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/commented",
+		endpoint.WithSuccessfulReturns([]response.Response{response.New(commentedModel{}, "200", "OK")}),
+	))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	model := openapi.Components.Schemas["swagno3.commentedModel"]
+	if model.Description != "A model described by its doc comment." {
+		t.Errorf("description = %q", model.Description)
+	}
+	want := map[string]string{
+		"id":    "ID doc.",
+		"email": "Email doc.",
+		"nick":  "Explicit description",
+		"plain": "",
+	}
+	got := map[string]string{}
+	for name, property := range propertiesMap(model.Properties) {
+		got[name] = property.Description
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("property descriptions mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type paramsPage struct {
+	Page  int `form:"page" default:"1" validate:"min=1"`
+	Limit int `form:"limit" binding:"max=100"`
+}
+
+type paramsModel struct {
+	paramsPage
+	ID       int64         `uri:"id"`
+	Token    string        `header:"X-Token" binding:"required"`
+	Session  string        `cookie:"session" deprecated:"true"`
+	Query    *string       `query:"q" validate:"min=3,max=50" desc:"Search terms" example:"shoes"`
+	Status   orderStatus   `form:"status"`
+	Statuses []orderStatus `form:"statuses" validate:"max=2,unique"`
+	Since    time.Time     `form:"since"`
+	Sort     string        `form:"sort" validate:"oneof=asc desc"`
+	Ratio    float32       `form:"ratio"`
+	Price    float64       `form:"price" validate:"gt=0,lte=9.99"`
+	Count    uint          `form:"count"`
+	Body     string        `json:"body"`
+	Ignored  string        `form:"-"`
+}
+
+func TestParamsFrom(t *testing.T) {
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	openapi.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/orders/{id}",
+		endpoint.WithParamsFrom(paramsModel{}),
+	))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	zero, one, hundred, price := float64(0), float64(1), float64(100), 9.99
+	two, three, fifty := int64(2), int64(3), int64(50)
+	statusValues := []interface{}{orderStatusNew, orderStatusPaid}
+	want := []parameter.JsonParameter{
+		{Name: "page", In: "query", Description: " (min: 1)", Schema: &parameter.JsonResponseSchema{Type: "integer", Format: "int64", Default: int64(1), Min: &one}},
+		{Name: "limit", In: "query", Description: " (max: 100)", Schema: &parameter.JsonResponseSchema{Type: "integer", Format: "int64", Max: &hundred}},
+		{Name: "id", In: "path", Required: true, Schema: &parameter.JsonResponseSchema{Type: "integer", Format: "int64"}},
+		{Name: "X-Token", In: "header", Required: true, Schema: &parameter.JsonResponseSchema{Type: "string"}},
+		{Name: "session", In: "cookie", Deprecated: true, Schema: &parameter.JsonResponseSchema{Type: "string"}},
+		{Name: "q", In: "query", Description: "Search terms\n (minLength: 3 maxLength: 50)", Example: "shoes", Schema: &parameter.JsonResponseSchema{Type: "string", MinLen: &three, MaxLen: &fifty, Example: "shoes"}},
+		{Name: "status", In: "query", Schema: &parameter.JsonResponseSchema{Type: "string", Enum: statusValues}},
+		{Name: "statuses", In: "query", Schema: &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "string", Enum: statusValues}, MaxItems: &two, UniqueItems: true}},
+		{Name: "since", In: "query", Schema: &parameter.JsonResponseSchema{Type: "string", Format: "date-time"}},
+		{Name: "sort", In: "query", Schema: &parameter.JsonResponseSchema{Type: "string", Enum: []interface{}{"asc", "desc"}}},
+		{Name: "ratio", In: "query", Schema: &parameter.JsonResponseSchema{Type: "number", Format: "float"}},
+		{Name: "price", In: "query", Description: " (exclusive min: 0 max: 9.99)", Schema: &parameter.JsonResponseSchema{Type: "number", Format: "double", Min: &zero, ExclusiveMin: true, Max: &price}},
+		{Name: "count", In: "query", Description: " (min: 0)", Schema: &parameter.JsonResponseSchema{Type: "integer", Format: "int64", Min: &zero}},
+	}
+	if diff := cmp.Diff(want, openapi.Paths["/orders/{id}"].Get.Parameters); diff != "" {
+		t.Errorf("parameters mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type typedParamsModel struct {
+	ID      uuidLike          `uri:"id"`
+	Related []uuidLike        `form:"related"`
+	Filter  paramsPage        `form:"filter"`
+	Labels  map[string]string `form:"labels"`
+	Legacy  string            `form:"legacy" deprecated:"true" example:"old"`
+}
+
+func TestParamsFromTypes(t *testing.T) {
+	cfg := Config{Title: "Testing API", Version: "v1.0.0"}
+	cfg.RegisterType(reflect.TypeOf(uuidLike{}), fields.TypeSchema{Type: "string", Format: "uuid"})
+	openapi := New(cfg)
+	openapi.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/orders/{id}",
+		endpoint.WithParamsFrom(typedParamsModel{}),
+	))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []parameter.JsonParameter{
+		{Name: "id", In: "path", Required: true, Schema: &parameter.JsonResponseSchema{Type: "string", Format: "uuid"}},
+		{Name: "related", In: "query", Schema: &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "string", Format: "uuid"}}},
+		{Name: "legacy", In: "query", Deprecated: true, Example: "old", Schema: &parameter.JsonResponseSchema{Type: "string", Example: "old"}},
+	}
+	if diff := cmp.Diff(want, openapi.Paths["/orders/{id}"].Get.Parameters); diff != "" {
+		t.Errorf("parameters mismatch (-want +got):\n%s", diff)
+	}
+
+	// without the registration, the uuid keeps the array of bytes it is in Go
+	params := parameter.ParamsFrom(typedParamsModel{})
+	if len(params) != 3 {
+		t.Fatalf("got %d parameters, want the struct and map fields skipped", len(params))
+	}
+	if got := params[0].AsJson(); got.Schema == nil || got.Schema.Type != "array" || got.Schema.Items.Type != "integer" {
+		t.Errorf("unregistered id = %+v, want an array of integers", got)
+	}
+}
+
+type level uint8
+
+func TestNumberParams(t *testing.T) {
+	openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+	openapi.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/prices",
+		endpoint.WithParams(
+			parameter.NumberParam("price", parameter.Query, parameter.WithMinimum(0.01), parameter.WithMaximum(0), parameter.WithMultipleOfNumber(0.01)),
+			parameter.NumberParam("ratio", parameter.Query, parameter.WithExclusiveMinimum(0), parameter.WithExclusiveMaximum(1)),
+			parameter.FloatEnumParam("scale", parameter.Query, []float64{0.5, 1, 2}),
+			parameter.IntParam("page", parameter.Query, parameter.WithMin(0), parameter.WithMax(10)),
+			parameter.NumberParam("budget", parameter.Query, parameter.WithMaximum(100), parameter.WithMultipleOfNumber(0.5), parameter.WithMax(0), parameter.WithMultipleOf(0)),
+			parameter.EnumParam("level", parameter.Query, level(0)),
+		),
+	))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	zero, cent, half, one, ten, hundred := float64(0), 0.01, 0.5, float64(1), float64(10), float64(100)
+	want := []parameter.JsonParameter{
+		{Name: "price", In: "query", Description: " (min: 0.01 max: 0)", Schema: &parameter.JsonResponseSchema{Type: "number", Min: &cent, Max: &zero, MultipleOf: &cent}},
+		{Name: "ratio", In: "query", Description: " (exclusive min: 0 exclusive max: 1)", Schema: &parameter.JsonResponseSchema{Type: "number", Min: &zero, Max: &one, ExclusiveMin: true, ExclusiveMax: true}},
+		{Name: "scale", In: "query", Schema: &parameter.JsonResponseSchema{Type: "number", Enum: []interface{}{0.5, 1.0, 2.0}}},
+		{Name: "page", In: "query", Description: " (max: 10)", Schema: &parameter.JsonResponseSchema{Type: "integer", Max: &ten}},
+		{Name: "budget", In: "query", Description: " (max: 100)", Schema: &parameter.JsonResponseSchema{Type: "number", Max: &hundred, MultipleOf: &half}},
+		{Name: "level", In: "query", Description: " (min: 0)", Schema: &parameter.JsonResponseSchema{Type: "integer", Format: "int32", Min: &zero}},
+	}
+	if diff := cmp.Diff(want, openapi.Paths["/prices"].Get.Parameters); diff != "" {
+		t.Errorf("parameters mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type arrayParamsModel struct {
+	IDs    []uint          `form:"ids" collection_format:"csv"`
+	Matrix [][]int32       `form:"matrix"`
+	Tags   []string        `header:"X-Tags"`
+	Days   [7]float64      `form:"days" collection_format:"multi"`
+	Levels []orderPriority `form:"levels"`
+}
+
+func TestArrayParams(t *testing.T) {
+	cfg := Config{Title: "Testing API", Version: "v1.0.0"}
+	cfg.RegisterEnum(reflect.TypeOf(orderPriority(0)), fields.Enum{Values: []interface{}{1, 2, 3}})
+	openapi := New(cfg)
+	openapi.AddEndpoint(endpoint.New(
+		endpoint.GET,
+		"/arrays/{ids}",
+		endpoint.WithParams(
+			parameter.IntArrParam("ids", parameter.Path, []int64{1, 2}, parameter.WithRequired(), parameter.WithCollectionFormat(parameter.CSV)),
+			parameter.StrArrParam("names", parameter.Query, nil, parameter.WithCollectionFormat(parameter.SSV)),
+			parameter.ArrParam("grid", parameter.Query, &parameter.JsonResponseSchemeItems{
+				Type:  "array",
+				Items: &parameter.JsonResponseSchemeItems{Type: "integer", Format: "int32"},
+			}, parameter.WithCollectionFormat(parameter.Pipes)),
+			parameter.StrArrParam("flags", parameter.Query, nil, parameter.WithCollectionFormat(parameter.Multi), parameter.WithStyle("deepObject")),
+		),
+		endpoint.WithParamsFrom(arrayParamsModel{}),
+	))
+	if err := openapi.generateOpenAPIJson(); err != nil {
+		t.Fatal(err)
+	}
+
+	zero := float64(0)
+	exploded, joined := true, false
+	want := []parameter.JsonParameter{
+		{Name: "ids", In: "path", Required: true, Style: "simple", Explode: &joined, Schema: &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "integer", Enum: []interface{}{int64(1), int64(2)}}}},
+		{Name: "names", In: "query", Style: "spaceDelimited", Explode: &joined, Schema: &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "string"}}},
+		{Name: "grid", In: "query", Style: "pipeDelimited", Explode: &joined, Schema: &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "integer", Format: "int32"}}}},
+		{Name: "flags", In: "query", Style: "deepObject", Schema: &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "string"}}},
+		{Name: "ids", In: "query", Style: "form", Explode: &joined, Schema: &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "integer", Format: "int64", Min: &zero}}},
+		{Name: "matrix", In: "query", Schema: &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "integer", Format: "int32"}}}},
+		{Name: "X-Tags", In: "header", Schema: &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "string"}}},
+		{Name: "days", In: "query", Style: "form", Explode: &exploded, Schema: &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "number", Format: "double"}}},
+		{Name: "levels", In: "query", Schema: &parameter.JsonResponseSchema{Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "integer", Format: "int64", Enum: []interface{}{1, 2, 3}}}},
+	}
+	if diff := cmp.Diff(want, openapi.Paths["/arrays/{ids}"].Get.Parameters); diff != "" {
+		t.Errorf("parameters mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPathParams(t *testing.T) {
+	newOpenAPI := func(auto bool, endpoints ...*endpoint.EndPoint) (*OpenAPI, error) {
+		openapi := New(Config{Title: "Testing API", Version: "v1.0.0", AutoPathParams: auto})
+		openapi.AddEndpoints(endpoints)
+		return openapi, openapi.generateOpenAPIJson()
+	}
+
+	t.Run("auto", func(t *testing.T) {
+		openapi, err := newOpenAPI(true,
+			endpoint.New(endpoint.GET, "/orders/{orderId}/items/{itemId}",
+				endpoint.WithParams(parameter.IntParam("orderId", parameter.Path, parameter.WithRequired())),
+			),
+			endpoint.New(endpoint.GET, "/files/:bucket/*path"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []parameter.JsonParameter{
+			{Name: "orderId", In: "path", Required: true, Schema: &parameter.JsonResponseSchema{Type: "integer"}},
+			{Name: "itemId", In: "path", Required: true, Schema: &parameter.JsonResponseSchema{Type: "string"}},
+		}
+		if diff := cmp.Diff(want, openapi.Paths["/orders/{orderId}/items/{itemId}"].Get.Parameters); diff != "" {
+			t.Errorf("parameters mismatch (-want +got):\n%s", diff)
+		}
+
+		pathItem, ok := openapi.Paths["/files/{bucket}/{path}"]
+		if !ok {
+			t.Fatalf("gin path not converted, paths: %v", openapi.Paths)
+		}
+		want = []parameter.JsonParameter{
+			{Name: "bucket", In: "path", Required: true, Schema: &parameter.JsonResponseSchema{Type: "string"}},
+			{Name: "path", In: "path", Required: true, Schema: &parameter.JsonResponseSchema{Type: "string"}},
+		}
+		if diff := cmp.Diff(want, pathItem.Get.Parameters); diff != "" {
+			t.Errorf("parameters mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("opt-in", func(t *testing.T) {
+		openapi, err := newOpenAPI(false, endpoint.New(endpoint.GET, "/orders/{orderId}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if params := openapi.Paths["/orders/{orderId}"].Get.Parameters; len(params) != 0 {
+			t.Errorf("parameters = %v, want none without AutoPathParams", params)
+		}
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		_, err := newOpenAPI(true,
+			endpoint.New(endpoint.GET, "/orders/:orderId",
+				endpoint.WithParams(
+					parameter.IntParam("orderId", parameter.Path),
+					parameter.IntParam("itemId", parameter.Path, parameter.WithRequired()),
+				),
+			),
+		)
+		var pathErr *PathParamError
+		if !errors.As(err, &pathErr) {
+			t.Fatalf("err = %v, want a *PathParamError", err)
+		}
+		want := []string{
+			`GET /orders/{orderId}: path parameter "orderId" is not required`,
+			`GET /orders/{orderId}: path parameter "itemId" is not in the path`,
+		}
+		var got []string
+		for _, err := range pathErr.Errors {
+			got = append(got, err.Error())
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("errors mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		_, err := newOpenAPI(true,
+			endpoint.New(endpoint.GET, "/orders/:orderId"),
+			endpoint.New(endpoint.DELETE, "/orders/:orderId"),
+			endpoint.New(endpoint.GET, "/orders/{orderId}"),
+		)
+		var duplicateErr *DuplicateOperationError
+		if !errors.As(err, &duplicateErr) {
+			t.Fatalf("err = %v, want a *DuplicateOperationError", err)
+		}
+		want := []string{`GET /orders/{orderId}: already documented by GET /orders/:orderId`}
+		var got []string
+		for _, err := range duplicateErr.Errors {
+			got = append(got, err.Error())
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("errors mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+type componentsError struct {
+	Message string `json:"message"`
+}
+
+type componentsOrder struct {
+	ID int `json:"id"`
+}
+
+func TestComponentRefs(t *testing.T) {
+	newOpenAPI := func(endpoints ...*endpoint.EndPoint) *OpenAPI {
+		openapi := New(Config{Title: "Testing API", Version: "v1.0.0", AutoPathParams: true})
+		openapi.RegisterParameter("orderId", parameter.IntParam("orderId", parameter.Path, parameter.WithRequired()))
+		openapi.RegisterParameter("page", parameter.IntParam("page", parameter.Query, parameter.WithDefault(1)))
+		openapi.RegisterHeader("X-Request-Id", ComponentHeader{Description: "Request id", Schema: &definition.Schema{Type: "string"}})
+		openapi.RegisterResponse("Unauthorized", response.New(componentsError{}, "401", "Unauthorized").WithHeaderRefs("X-Request-Id"))
+		openapi.RegisterRequestBody("Order", componentsOrder{}, endpoint.WithBodyDescription("Order to save"))
+		openapi.AddEndpoints(endpoints)
+		return openapi
+	}
+
+	t.Run("refs", func(t *testing.T) {
+		openapi := newOpenAPI(endpoint.New(endpoint.PUT, "/orders/{orderId}",
+			endpoint.WithParamRefs("orderId", "page"),
+			endpoint.WithBodyRef("Order"),
+			endpoint.WithSuccessfulReturns([]response.Response{response.New(componentsOrder{}, "200", "OK")}),
+			endpoint.WithResponseRefs("Unauthorized"),
+		))
+		if err := openapi.generateOpenAPIJson(); err != nil {
+			t.Fatal(err)
+		}
+
+		operation := openapi.Paths["/orders/{orderId}"].Put
+		got, err := json.Marshal(map[string]interface{}{
+			"parameters":  operation.Parameters,
+			"requestBody": operation.RequestBody,
+			"401":         operation.Responses["401"],
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `{"401":{"$ref":"#/components/responses/Unauthorized"},` +
+			`"parameters":[{"$ref":"#/components/parameters/orderId"},{"$ref":"#/components/parameters/page"}],` +
+			`"requestBody":{"$ref":"#/components/requestBodies/Order"}}`
+		if string(got) != want {
+			t.Errorf("operation refs = %s, want %s", got, want)
+		}
+		if _, ok := operation.Responses["200"]; !ok {
+			t.Errorf("responses = %v, want the successful return kept", operation.Responses)
+		}
+
+		components := openapi.Components
+		wantParam := parameter.JsonParameter{Name: "page", In: "query", Schema: &parameter.JsonResponseSchema{Type: "integer", Default: 1}}
+		if diff := cmp.Diff(wantParam, components.Parameters["page"]); diff != "" {
+			t.Errorf("page parameter mismatch (-want +got):\n%s", diff)
+		}
+		unauthorized := components.Responses["Unauthorized"]
+		if got := unauthorized.Content["application/json"].Schema.Ref; got != "#/components/schemas/swagno3.componentsError" {
+			t.Errorf("Unauthorized schema = %q, want the componentsError schema", got)
+		}
+		if got := unauthorized.Headers["X-Request-Id"]; !reflect.DeepEqual(got, map[string]string{"$ref": "#/components/headers/X-Request-Id"}) {
+			t.Errorf("Unauthorized header = %v, want a reference to X-Request-Id", got)
+		}
+		order := components.RequestBodies["Order"]
+		if order.Description != "Order to save" || !order.Required || order.Content["application/json"].Schema.Ref != "#/components/schemas/swagno3.componentsOrder" {
+			t.Errorf("Order request body = %+v, want the described, required componentsOrder body", order)
+		}
+		for _, name := range []string{"swagno3.componentsError", "swagno3.componentsOrder"} {
+			if _, ok := components.Schemas[name]; !ok {
+				t.Errorf("schema %s missing", name)
+			}
+		}
+	})
+
+	t.Run("unregistered", func(t *testing.T) {
+		openapi := newOpenAPI(endpoint.New(endpoint.POST, "/orders",
+			endpoint.WithParamRefs("limit"),
+			endpoint.WithBodyRef("Cart"),
+			endpoint.WithErrors([]response.Response{response.New(componentsError{}, "429", "Too Many Requests").WithHeaderRefs("Retry-After")}),
+			endpoint.WithResponseRefs("NotFound"),
+		))
+		err := openapi.generateOpenAPIJson()
+		var refErr *ComponentRefError
+		if !errors.As(err, &refErr) {
+			t.Fatalf("err = %v, want a *ComponentRefError", err)
+		}
+		want := []string{
+			`POST /orders: parameter "limit" is not registered`,
+			`POST /orders: response "NotFound" is not registered`,
+			`POST /orders: header "Retry-After" is not registered`,
+			`POST /orders: request body "Cart" is not registered`,
+		}
+		var got []string
+		for _, err := range refErr.Errors {
+			got = append(got, err.Error())
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("errors mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("form parameters", func(t *testing.T) {
+		openapi := newOpenAPI(endpoint.New(endpoint.POST, "/avatars", endpoint.WithParamRefs("avatar")))
+		openapi.RegisterParameter("avatar", parameter.FileParam("avatar", parameter.WithRequired()))
+		if _, ok := openapi.parameters["avatar"]; ok {
+			t.Error("expected the form parameter not to be registered")
+		}
+		err := openapi.generateOpenAPIJson()
+		var refErr *ComponentRefError
+		if !errors.As(err, &refErr) {
+			t.Fatalf("err = %v, want a *ComponentRefError", err)
+		}
+		want := []string{
+			`parameter "avatar" is a form parameter, which is documented in the request body`,
+			`POST /avatars: parameter "avatar" is not registered`,
+		}
+		var got []string
+		for _, err := range refErr.Errors {
+			got = append(got, err.Error())
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("errors mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+type formUploadRequest struct {
+	UserID      string                  `uri:"userId"`
+	Avatar      *multipart.FileHeader   `form:"avatar" binding:"required" content_type:"image/png, image/jpeg"`
+	Attachments []*multipart.FileHeader `form:"attachments"`
+	Caption     string                  `form:"caption" desc:"Shown under the avatar" validate:"max=80"`
+}
+
+func TestFormBody(t *testing.T) {
+	newOpenAPI := func(endpoints ...*endpoint.EndPoint) *OpenAPI {
+		openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+		openapi.AddEndpoints(endpoints)
+		if err := openapi.generateOpenAPIJson(); err != nil {
+			t.Fatal(err)
+		}
+		return openapi
+	}
+
+	t.Run("params", func(t *testing.T) {
+		openapi := newOpenAPI(endpoint.New(endpoint.POST, "/avatars",
+			endpoint.WithParams(
+				parameter.BoolParam("notify", parameter.Query),
+				parameter.FileParam("avatar", parameter.WithRequired(), parameter.WithContentType("image/png")),
+				parameter.StrParam("caption", parameter.Form, parameter.WithDescription("Caption")),
+			),
+		))
+
+		operation := openapi.Paths["/avatars"].Post
+		if len(operation.Parameters) != 1 || operation.Parameters[0].Name != "notify" {
+			t.Errorf("parameters = %+v, want only the notify query parameter", operation.Parameters)
+		}
+		want := &endpoint.RequestBody{
+			Description: "Request body",
+			Required:    true,
+			Content: map[string]endpoint.MediaType{
+				"multipart/form-data": {
+					Schema: &parameter.JsonResponseSchema{
+						Type: "object",
+						Properties: map[string]*parameter.JsonResponseSchema{
+							"avatar":  {Type: "string", Format: "binary"},
+							"caption": {Type: "string", Description: "Caption"},
+						},
+						Required: []string{"avatar"},
+					},
+					Encoding: map[string]interface{}{
+						"avatar": &http.Encoding{ContentType: "image/png"},
+					},
+				},
+			},
+		}
+		if diff := cmp.Diff(want, operation.RequestBody); diff != "" {
+			t.Errorf("request body mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		pdf := &http.Encoding{ContentType: "application/pdf"}
+		maxLen := int64(80)
+		openapi := newOpenAPI(endpoint.New(endpoint.PUT, "/users/{userId}/avatar",
+			endpoint.WithFormParamsFrom(formUploadRequest{}),
+			endpoint.WithFormEncoding("attachments", pdf),
+		))
+
+		operation := openapi.Paths["/users/{userId}/avatar"].Put
+		if len(operation.Parameters) != 1 || operation.Parameters[0].Name != "userId" {
+			t.Errorf("parameters = %+v, want only the userId path parameter", operation.Parameters)
+		}
+		want := map[string]endpoint.MediaType{
+			"multipart/form-data": {
+				Schema: &parameter.JsonResponseSchema{
+					Type: "object",
+					Properties: map[string]*parameter.JsonResponseSchema{
+						"avatar":      {Type: "string", Format: "binary"},
+						"attachments": {Type: "array", Items: &parameter.JsonResponseSchemeItems{Type: "string", Format: "binary"}},
+						"caption":     {Type: "string", Description: "Shown under the avatar\n (maxLength: 80)", MaxLen: &maxLen},
+					},
+					Required: []string{"avatar"},
+				},
+				Encoding: map[string]interface{}{
+					"avatar":      &http.Encoding{ContentType: "image/png, image/jpeg"},
+					"attachments": pdf,
+				},
+			},
+		}
+		if diff := cmp.Diff(want, operation.RequestBody.Content); diff != "" {
+			t.Errorf("request body mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("urlencoded", func(t *testing.T) {
+		openapi := newOpenAPI(endpoint.New(endpoint.POST, "/login",
+			endpoint.WithConsume([]mime.MIME{mime.URLFORM}),
+			endpoint.WithParams(
+				parameter.StrParam("username", parameter.Form, parameter.WithRequired()),
+				parameter.StrParam("password", parameter.Form, parameter.WithRequired(), parameter.WithFormat("password")),
+			),
+		))
+
+		content := openapi.Paths["/login"].Post.RequestBody.Content
+		if len(content) != 1 {
+			t.Fatalf("content = %v, want only application/x-www-form-urlencoded", content)
+		}
+		schema := content["application/x-www-form-urlencoded"].Schema
+		if schema == nil || schema.Type != "object" || len(schema.Properties) != 2 || !reflect.DeepEqual(schema.Required, []string{"username", "password"}) {
+			t.Errorf("schema = %+v, want an object of the required username and password", schema)
+		}
+	})
+
+	t.Run("body", func(t *testing.T) {
+		openapi := newOpenAPI(endpoint.New(endpoint.POST, "/orders",
+			endpoint.WithBody(componentsOrder{}),
+			endpoint.WithParams(parameter.FileParam("receipt")),
+		))
+
+		content := openapi.Paths["/orders"].Post.RequestBody.Content
+		if got := content["application/json"].Schema.Ref; got != "#/components/schemas/swagno3.componentsOrder" {
+			t.Errorf("json body schema = %q, want the componentsOrder schema", got)
+		}
+		if got := content["multipart/form-data"].Schema.Properties["receipt"]; got == nil || got.Format != "binary" {
+			t.Errorf("form body receipt = %+v, want a binary file", got)
+		}
+	})
+
+	t.Run("twice", func(t *testing.T) {
+		openapi := New(Config{Title: "Testing API", Version: "v1.0.0"})
+		openapi.AddEndpoint(endpoint.New(endpoint.POST, "/avatars",
+			endpoint.WithParams(
+				parameter.FileParam("avatar", parameter.WithRequired(), parameter.WithDescription("Avatar")),
+				parameter.StrParam("caption", parameter.Form, parameter.WithDescription("Caption")),
+			),
+		))
+
+		first, err := openapi.ToJson()
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := openapi.ToJson()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(string(first), string(second)); diff != "" {
+			t.Errorf("second document mismatch (-first +second):\n%s", diff)
+		}
+		property := openapi.Paths["/avatars"].Post.RequestBody.Content["multipart/form-data"].Schema.Properties["avatar"]
+		if property.Type != "string" || property.Format != "binary" {
+			t.Errorf("avatar = %+v, want a binary string", property)
+		}
+	})
+}
+
+// propertiesMap returns the properties keyed by name, for comparisons ignoring their order.
+func propertiesMap(properties definition.Properties) map[string]definition.SchemaProperty {
+	m := map[string]definition.SchemaProperty{}
+	for _, property := range properties {
+		m[property.Name] = property.Schema
+	}
+	return m
+}
+
+// equateExamples compares examples by their JSON encoding, since examples decoded from
+// the expected output are float64, []interface{} or map[string]interface{} values while
+// generated ones keep the type of their field.
+func equateExamples() cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		field, ok := p.Last().(cmp.StructField)
+		return ok && field.Name() == "Example"
+	}, cmp.Comparer(func(x, y interface{}) bool {
+		a, errA := json.Marshal(x)
+		b, errB := json.Marshal(y)
+		return errA == nil && errB == nil && string(a) == string(b)
+	}))
+}
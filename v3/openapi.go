@@ -4,10 +4,13 @@ import (
 	"encoding/json"
 	"log"
 	"os"
+	"reflect"
 
 	"github.com/go-swagno/swagno/v3/components/definition"
 	"github.com/go-swagno/swagno/v3/components/endpoint"
 	"github.com/go-swagno/swagno/v3/components/extensions"
+	"github.com/go-swagno/swagno/v3/components/fields"
+	"github.com/go-swagno/swagno/v3/components/http/response"
 	"github.com/go-swagno/swagno/v3/components/parameter"
 	"github.com/go-swagno/swagno/v3/components/security"
 	"github.com/go-swagno/swagno/v3/components/tag"
@@ -28,6 +31,20 @@ type OpenAPI struct {
 
 	endpoints       []*endpoint.EndPoint
 	hidePackageName bool
+	types           fields.Types
+	enums           fields.Enums
+	enumSchemas     bool
+	interfaces      fields.Interfaces
+	embeddedAllOf   bool
+	nameStrategy    fields.NameStrategy
+	comments        fields.Comments
+	autoPathParams  bool
+	strictExamples  bool
+	exampleErrors   []error
+	parameters      map[string]*parameter.Parameter
+	parameterErrors []error
+	responses       map[string]response.Response
+	requestBodies   map[string]*endpoint.EndPoint
 }
 
 func (o OpenAPI) MarshalJSON() ([]byte, error) {
@@ -199,6 +216,74 @@ type Config struct {
 	// HidePackageName, when true, references models without their package qualifier
 	// in the generated documentation (e.g. "MyStruct" instead of "models.MyStruct").
 	HidePackageName bool
+	// Types maps Go types to the schema they are documented with, overriding reflection.
+	// Use RegisterType to add entries. Built-in mappings exist for time.Time, []byte,
+	// json.RawMessage, net.IP, url.URL and the sql.Null* types.
+	Types fields.Types
+	// Enums maps named types to their allowed values. Use RegisterEnum to add entries.
+	// Types implementing fields.Enumer do not need to be registered.
+	Enums fields.Enums
+	// EnumSchemas, when true, documents enum types as reusable component schemas with
+	// an 'x-enum-varnames' extension instead of repeating their values inline.
+	EnumSchemas bool
+	// Interfaces maps interface types to their concrete implementations. Use
+	// RegisterInterface to add entries. Unregistered interfaces are documented as
+	// unconstrained values.
+	Interfaces fields.Interfaces
+	// EmbeddedAllOf, when true, documents structs with embedded structs as an allOf of
+	// references to the embedded structs' schemas followed by their own fields, keeping
+	// the "is-a" relationship. Embedded structs whose fields are shadowed by, or conflict
+	// with, other fields are still flattened. By default promoted fields are flattened
+	// into the schema.
+	EmbeddedAllOf bool
+	// NameStrategy names the component schemas of models and the references to them, e.g.
+	// fields.PathSegmentNames(2) or a fields.NameFunc callback. It defaults to
+	// fields.QualifiedNames, or fields.UnqualifiedNames with HidePackageName.
+	NameStrategy fields.NameStrategy
+	// GenericName names the instantiations of generic types for the built-in name
+	// strategies, e.g. "models.PageOfProduct" instead of the default "models.Page_Product".
+	GenericName fields.GenericNameFunc
+	// Comments holds the doc comments of models and their fields, which describe the
+	// schemas and properties without a 'desc' tag. It is usually generated by the
+	// swagno-comments command and decoded with fields.ParseComments.
+	Comments fields.Comments
+	// AutoPathParams, when true, documents the '{name}' segments of endpoint paths that
+	// no path parameter is declared for as required string parameters.
+	AutoPathParams bool
+	// StrictExamples, when true, makes ToJson return an *ExampleError (and MustToJson
+	// panic) when 'example' struct tags cannot be converted to the type of their field.
+	// By default these examples are documented as written, logged and reported by
+	// ExampleErrors.
+	StrictExamples bool
+}
+
+// RegisterType documents every value of type t with the given schema instead of
+// reflecting it, e.g. RegisterType(reflect.TypeOf(uuid.UUID{}), fields.TypeSchema{Type: "string", Format: "uuid"}).
+func (c *Config) RegisterType(t reflect.Type, schema fields.TypeSchema) {
+	if c.Types == nil {
+		c.Types = fields.Types{}
+	}
+	c.Types[t] = schema
+}
+
+// RegisterEnum documents the allowed values of the named type t on every property,
+// array item and parameter of that type, e.g.
+// RegisterEnum(reflect.TypeOf(OrderStatus("")), fields.Enum{Values: []interface{}{StatusNew, StatusPaid}}).
+func (c *Config) RegisterEnum(t reflect.Type, enum fields.Enum) {
+	if c.Enums == nil {
+		c.Enums = fields.Enums{}
+	}
+	c.Enums[t] = enum
+}
+
+// RegisterInterface documents fields of the interface type t as oneOf the given
+// implementations with a discriminator, e.g.
+// RegisterInterface(reflect.TypeOf((*Shape)(nil)).Elem(), fields.Implementations{PropertyName: "kind", Mapping: map[string]interface{}{"circle": Circle{}}}).
+func (c *Config) RegisterInterface(t reflect.Type, implementations fields.Implementations) {
+	if c.Interfaces == nil {
+		c.Interfaces = fields.Interfaces{}
+	}
+	c.Interfaces[t] = implementations
 }
 
 // buildOpenAPI creates a new OpenAPI instance with the given configuration.
@@ -210,6 +295,16 @@ func buildOpenAPI(c Config) (openapi *OpenAPI) {
 		c.Version = "1.0.0"
 	}
 
+	if c.NameStrategy == nil {
+		c.NameStrategy = fields.QualifiedNames()
+		if c.HidePackageName {
+			c.NameStrategy = fields.UnqualifiedNames()
+		}
+	}
+	if c.GenericName != nil {
+		c.NameStrategy = fields.GenericNames(c.NameStrategy, c.GenericName)
+	}
+
 	openapi = &OpenAPI{
 		OpenAPI: "3.0.3",
 		Info: Info{
@@ -233,6 +328,15 @@ func buildOpenAPI(c Config) (openapi *OpenAPI) {
 		Tags:            []tag.Tag{},
 		endpoints:       []*endpoint.EndPoint{},
 		hidePackageName: c.HidePackageName,
+		types:           c.Types,
+		enums:           c.Enums,
+		enumSchemas:     c.EnumSchemas,
+		interfaces:      c.Interfaces,
+		embeddedAllOf:   c.EmbeddedAllOf,
+		nameStrategy:    c.NameStrategy,
+		comments:        c.Comments,
+		autoPathParams:  c.AutoPathParams,
+		strictExamples:  c.StrictExamples,
 	}
 
 	// Set default server if none provided and none will be added later
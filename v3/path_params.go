@@ -0,0 +1,86 @@
+package swagno3
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-swagno/swagno/v3/components/endpoint"
+	"github.com/go-swagno/swagno/v3/components/parameter"
+)
+
+// PathParamError is returned by ToJson (and panicked by MustToJson) when the path
+// parameters declared on endpoints do not match their path templates: a parameter
+// located in the path has no '{name}' segment in it, or is not required. OpenAPI
+// requires both, so the document would otherwise be invalid.
+type PathParamError struct {
+	// Errors describes each mismatch, in the order the endpoints were added.
+	Errors []error
+}
+
+func (e *PathParamError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return "swagno: invalid path parameters: " + strings.Join(messages, "; ")
+}
+
+// DuplicateOperationError is returned by ToJson (and panicked by MustToJson) when
+// endpoints document the same method on the same path template, e.g. '/orders/:id'
+// and '/orders/{id}'. OpenAPI documents one operation per method and path, so the later
+// endpoints would otherwise be lost.
+type DuplicateOperationError struct {
+	// Errors describes each duplicate, in the order the endpoints were added.
+	Errors []error
+}
+
+func (e *DuplicateOperationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return "swagno: duplicate operations: " + strings.Join(messages, "; ")
+}
+
+// undeclaredPathParams returns required string parameters for the '{name}' segments
+// of the path template that no parameter in params is declared for.
+func undeclaredPathParams(path string, params []*parameter.Parameter) []*parameter.Parameter {
+	declared := map[string]bool{}
+	for _, param := range params {
+		if param.Location() == parameter.Path {
+			declared[param.AsJson().Name] = true
+		}
+	}
+
+	var undeclared []*parameter.Parameter
+	for _, name := range endpoint.PathParamNames(path) {
+		if !declared[name] {
+			undeclared = append(undeclared, parameter.StrParam(name, parameter.Path, parameter.WithRequired()))
+			declared[name] = true
+		}
+	}
+	return undeclared
+}
+
+// pathParamErrors returns an error for each path parameter in params without a
+// '{name}' segment in the path template, or that is not required.
+func pathParamErrors(method endpoint.MethodType, path string, params []*parameter.Parameter) []error {
+	segments := map[string]bool{}
+	for _, name := range endpoint.PathParamNames(path) {
+		segments[name] = true
+	}
+
+	var errs []error
+	for _, param := range params {
+		if param.Location() != parameter.Path {
+			continue
+		}
+		pj := param.AsJson()
+		if !segments[pj.Name] {
+			errs = append(errs, fmt.Errorf("%s %s: path parameter %q is not in the path", method, path, pj.Name))
+		} else if !pj.Required {
+			errs = append(errs, fmt.Errorf("%s %s: path parameter %q is not required", method, path, pj.Name))
+		}
+	}
+	return errs
+}
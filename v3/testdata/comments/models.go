@@ -0,0 +1,16 @@
+// Package comments holds models whose doc comments are loaded by TestDocComments.
+package comments
+
+// User is a registered account.
+type User struct {
+	// Email is where notifications are sent.
+	Email string `json:"email"`
+	Name  string `json:"name"` // Name is the display name.
+	Age   int    `json:"age"`
+}
+
+type (
+	// Status is the state of an order.
+	Status string
+	Role   string
+)